@@ -2,31 +2,69 @@
 package core
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"explosio/core/material"
+	"explosio/core/resource"
 	"explosio/core/resource/asset"
 	"explosio/core/resource/human"
 	"explosio/core/unit"
 )
 
 // Activity represents a task with name, description, duration, price, sub-activities, and materials (complex, countable, measurable).
-// DependsOn defines explicit dependencies: this activity cannot start until all DependsOn activities have finished.
+// DependsOn defines explicit dependencies: by default this activity cannot start until all DependsOn activities have finished;
+// each Dependency can use another link type (SS, FF, SF) and a lag or lead.
+// ID identifies the activity within a project; DependsOn is persisted as references by ID (see serialization.go).
+// Constraint, if set, restricts when the activity can be scheduled (see constraint.go).
+// Estimate, if set, holds the three-point estimate used by PERT analysis (see pert.go); CostEstimate, if set,
+// holds the range of the own price used by Monte Carlo simulation (see simulation.go).
+// Assignments reference resources of the project pool (see Project.Resources); they are persisted by resource ID.
+// LevelingDelay, if set, delays the start after its predecessors; it is set by resource leveling (see leveling.go).
+// PercentComplete, ActualStart, ActualFinish and RemainingDuration record execution progress; with a project status
+// date the CPM schedules the remaining work from that date (see progress.go). ActualCost is the cost incurred so far
+// on the activity's own scope (price, materials, resources; not sub-activities), in the currency of its price, used
+// by earned value (see evm.go).
 type Activity struct {
+	ID                  string
 	Name                string
 	Description         string
 	Duration            unit.Duration
 	Price               unit.Price
 	Activities          []*Activity
-	DependsOn           []*Activity // Explicit dependencies (must finish before this starts)
+	DependsOn           []Dependency        `json:"-" yaml:"-"` // Explicit dependencies (predecessor links)
+	Constraint          *Constraint         `json:",omitempty" yaml:",omitempty"`
+	Estimate            *ThreePointEstimate `json:",omitempty" yaml:",omitempty"`
+	CostEstimate        *CostEstimate       `json:",omitempty" yaml:",omitempty"`
+	LevelingDelay       *unit.Duration      `json:",omitempty" yaml:",omitempty"`
+	PercentComplete     float64             `json:",omitempty" yaml:",omitempty"` // 0-100
+	ActualStart         *unit.Date          `json:",omitempty" yaml:",omitempty"`
+	ActualFinish        *unit.Date          `json:",omitempty" yaml:",omitempty"`
+	RemainingDuration   *unit.Duration      `json:",omitempty" yaml:",omitempty"` // Work left (nil = planned duration × (1 - percent))
+	ActualCost          float64             `json:",omitempty" yaml:",omitempty"`
 	ComplexMaterials    []*material.ComplexMaterial
 	CountableMaterials  []*material.CountableMaterial
 	MeasurableMaterials []*material.MeasurableMaterial
 	HumanResources      []*human.HumanResource
 	Assets              []*asset.Asset
+	Assignments         []*resource.Assignment `json:"-" yaml:"-"` // Assignments of pool resources
+
+	pendingDeps        []dependencyRef // DependsOn references read from file, resolved by Project.resolveDependencies
+	pendingAssignments []assignmentRef // Assignments read from file, resolved by Project.resolveAssignments
 }
 
-// NewActivity creates an activity with name and description, zero duration and zero EUR price.
+// NewActivityID returns a new random activity ID (16 hex characters).
+func NewActivityID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic("activity ID: " + err.Error())
+	}
+	return hex.EncodeToString(b)
+}
+
+// NewActivity creates an activity with a new ID, name, description, duration and price.
 func NewActivity(name string, description string, duration unit.Duration, price unit.Price) *Activity {
 	return &Activity{
+		ID:          NewActivityID(),
 		Name:        name,
 		Description: description,
 		Duration:    duration,
@@ -39,9 +77,15 @@ func (a *Activity) AddActivity(activity *Activity) {
 	a.Activities = append(a.Activities, activity)
 }
 
-// AddDependsOn adds an explicit dependency: the given activity must finish before this one starts.
+// AddDependsOn adds an explicit finish-to-start dependency: the given activity must finish before this one starts.
 func (a *Activity) AddDependsOn(activity *Activity) {
-	a.DependsOn = append(a.DependsOn, activity)
+	a.AddDependency(activity, FinishToStart, unit.Duration{Value: 0, Unit: unit.DurationUnitDay})
+}
+
+// AddDependency adds an explicit dependency of the given type with a lag (negative lag = lead).
+// Example: AddDependency(plastering, StartToStart, 2 days) lets this activity start 2 days after plastering starts.
+func (a *Activity) AddDependency(activity *Activity, depType DependencyType, lag unit.Duration) {
+	a.DependsOn = append(a.DependsOn, Dependency{Activity: activity, Type: depType, Lag: lag})
 }
 
 // AddComplexMaterial adds a complex material.
@@ -69,17 +113,72 @@ func (a *Activity) AddAsset(asset *asset.Asset) {
 	a.Assets = append(a.Assets, asset)
 }
 
+// Assign assigns a pool resource to the activity with the given units (1 = full time) and work hours,
+// and returns the assignment. The resource should belong to the project pool (see Project.AddResource).
+func (a *Activity) Assign(r *resource.Resource, units float64, workHours float64) *resource.Assignment {
+	as := resource.NewAssignment(r, units, workHours)
+	a.Assignments = append(a.Assignments, as)
+	return as
+}
+
 // IsMilestone returns true if the activity has zero duration (a checkpoint/milestone).
 func (a *Activity) IsMilestone() bool {
 	return a.Duration.Value == 0
 }
 
 // Clone returns a deep copy of the activity and its subtree (materials, resources, sub-activities).
-// DependsOn references are not cloned (they would point to the original tree); clone a full project to preserve dependencies.
+// Clones keep the IDs of the originals. DependsOn references between activities of the subtree are
+// remapped to the cloned activities; references to activities outside the subtree are dropped.
 func (a *Activity) Clone() *Activity {
+	clones := make(map[*Activity]*Activity)
+	clone := a.cloneTree(clones)
+	for orig, c := range clones {
+		for _, dep := range orig.DependsOn {
+			if cdep, ok := clones[dep.Activity]; ok {
+				c.AddDependency(cdep, dep.Type, dep.Lag)
+			}
+		}
+	}
+	return clone
+}
+
+// cloneTree copies the subtree without dependencies, recording original -> clone in clones.
+func (a *Activity) cloneTree(clones map[*Activity]*Activity) *Activity {
 	clone := NewActivity(a.Name, a.Description, a.Duration, a.Price)
+	clone.ID = a.ID
+	if a.Constraint != nil {
+		c := *a.Constraint
+		clone.Constraint = &c
+	}
+	if a.Estimate != nil {
+		e := *a.Estimate
+		clone.Estimate = &e
+	}
+	if a.CostEstimate != nil {
+		c := *a.CostEstimate
+		clone.CostEstimate = &c
+	}
+	if a.LevelingDelay != nil {
+		d := *a.LevelingDelay
+		clone.LevelingDelay = &d
+	}
+	clone.PercentComplete = a.PercentComplete
+	clone.ActualCost = a.ActualCost
+	if a.ActualStart != nil {
+		d := *a.ActualStart
+		clone.ActualStart = &d
+	}
+	if a.ActualFinish != nil {
+		d := *a.ActualFinish
+		clone.ActualFinish = &d
+	}
+	if a.RemainingDuration != nil {
+		d := *a.RemainingDuration
+		clone.RemainingDuration = &d
+	}
+	clones[a] = clone
 	for _, child := range a.Activities {
-		clone.AddActivity(child.Clone())
+		clone.AddActivity(child.cloneTree(clones))
 	}
 	for _, m := range a.ComplexMaterials {
 		clone.AddComplexMaterial(m.Clone())
@@ -96,5 +195,8 @@ func (a *Activity) Clone() *Activity {
 	for _, as := range a.Assets {
 		clone.AddAsset(as.Clone())
 	}
+	for _, as := range a.Assignments {
+		clone.Assignments = append(clone.Assignments, as.Clone())
+	}
 	return clone
 }
@@ -41,7 +41,20 @@ func (b *ActivityBuilder) WithPrice(price unit.Price) *ActivityBuilder {
 	return b
 }
 
-// Build returns the built activity. Returns an error if name is empty, duration is negative, or price is invalid (negative value or empty currency).
+// WithEstimate sets the three-point estimate and returns the builder for chaining.
+func (b *ActivityBuilder) WithEstimate(estimate *ThreePointEstimate) *ActivityBuilder {
+	b.activity.Estimate = estimate
+	return b
+}
+
+// WithCostEstimate sets the cost estimate (range of the own price) and returns the builder for chaining.
+func (b *ActivityBuilder) WithCostEstimate(estimate *CostEstimate) *ActivityBuilder {
+	b.activity.CostEstimate = estimate
+	return b
+}
+
+// Build returns the built activity. Returns an error if name is empty, duration is negative, price is invalid (negative value or empty currency),
+// or an estimate is invalid (not ordered optimistic <= most likely <= pessimistic, or unknown distribution).
 func (b *ActivityBuilder) Build() (*Activity, error) {
 	if b.activity.Name == "" {
 		return nil, errors.New("activity name cannot be empty")
@@ -55,5 +68,11 @@ func (b *ActivityBuilder) Build() (*Activity, error) {
 	if b.activity.Price.Currency == "" {
 		return nil, errors.New("activity price currency cannot be empty")
 	}
+	if b.activity.Estimate != nil && !b.activity.Estimate.valid() {
+		return nil, errors.New("activity estimate must satisfy 0 <= optimistic <= most likely <= pessimistic with a known distribution")
+	}
+	if b.activity.CostEstimate != nil && !b.activity.CostEstimate.valid() {
+		return nil, errors.New("activity cost estimate must satisfy 0 <= optimistic <= most likely <= pessimistic with a known distribution")
+	}
 	return b.activity, nil
 }
@@ -85,3 +85,23 @@ func TestActivityBuilder_Build_validation(t *testing.T) {
 		}
 	})
 }
+
+func TestActivityBuilder_WithEstimate(t *testing.T) {
+	_, err := NewActivityBuilder().
+		WithName("A").
+		WithEstimate(NewThreePointEstimate(3, 2, 4, unit.DurationUnitDay)).
+		Build()
+	if err == nil {
+		t.Error("expected an error for an unordered estimate")
+	}
+	a, err := NewActivityBuilder().
+		WithName("A").
+		WithEstimate(NewThreePointEstimate(1, 2, 4, unit.DurationUnitDay)).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Estimate == nil || a.Estimate.MostLikely.Value != 2 {
+		t.Errorf("Estimate = %+v, want most likely 2", a.Estimate)
+	}
+}
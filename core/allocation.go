@@ -0,0 +1,224 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"explosio/core/resource"
+)
+
+// ResourceBooking is the use of a resource by an activity during the activity's own work window [From, To) (hours).
+type ResourceBooking struct {
+	Activity *Activity
+	From     float64
+	To       float64
+	Units    float64
+}
+
+// LoadSegment is an interval [From, To) (hours) in which the load of a resource is constant.
+type LoadSegment struct {
+	From       float64
+	To         float64
+	Units      float64
+	Activities []*Activity
+}
+
+// ResourceLoad is the load of a resource over time. Pool resources are identified by pointer; per-activity
+// human resources and assets are identified by name (case-insensitive) and have a capacity of 1.
+type ResourceLoad struct {
+	Name     string
+	Resource *resource.Resource // Pool resource, nil for per-activity human resources and assets
+	Capacity float64
+	Bookings []ResourceBooking
+	Segments []LoadSegment // Intervals with a non-zero load, ordered by time
+}
+
+// OverAllocation is an interval in which a resource is booked beyond its capacity.
+type OverAllocation struct {
+	Resource   string
+	From       float64 // Hours from project start
+	To         float64
+	Load       float64 // Peak units booked in the interval
+	Capacity   float64
+	Activities []*Activity
+}
+
+// ResourceLoads returns the load of every resource, sorted by name, using the early schedule with calendar-time
+// conversion; see Project.ResourceLoads for the project settings.
+func (a *Activity) ResourceLoads() []*ResourceLoad {
+	return a.resourceLoads(defaultScheduleOptions())
+}
+
+// OverAllocations returns the intervals in which a resource is booked beyond its capacity, using calendar time.
+func (a *Activity) OverAllocations() []OverAllocation {
+	return overAllocations(a.ResourceLoads())
+}
+
+// resourceDemand is the use of a resource by an activity: key identifies the resource across activities.
+type resourceDemand struct {
+	key      string
+	name     string
+	resource *resource.Resource
+	capacity float64
+	units    float64
+}
+
+// resourceDemands returns the resources used by the activity: per-activity human resources and assets
+// (matched by name, 1 unit of a capacity of 1) and pool assignments (matched by resource).
+func (a *Activity) resourceDemands() []resourceDemand {
+	var d []resourceDemand
+	for _, h := range a.HumanResources {
+		d = append(d, resourceDemand{key: "human:" + resourceKey(h.Name), name: h.Name, capacity: 1, units: 1})
+	}
+	for _, as := range a.Assets {
+		d = append(d, resourceDemand{key: "asset:" + resourceKey(as.Name), name: as.Name, capacity: 1, units: 1})
+	}
+	for _, as := range a.Assignments {
+		if as.Resource == nil {
+			continue
+		}
+		d = append(d, resourceDemand{
+			key:      fmt.Sprintf("pool:%p", as.Resource),
+			name:     as.Resource.Name,
+			resource: as.Resource,
+			capacity: as.Resource.Capacity(),
+			units:    as.Units,
+		})
+	}
+	return d
+}
+
+// resourceLoads books each resource of an activity over the activity's own work window [ES, EF) (ES + duration,
+// or the finish given progress).
+// Milestones (zero duration) do not book resources.
+func (a *Activity) resourceLoads(opts scheduleOptions) []*ResourceLoad {
+	m, _ := a.calculateSlack(opts)
+	loads := make(map[string]*ResourceLoad)
+	for _, act := range a.GetActivities() {
+		info, ok := m[act]
+		hours := opts.activityHours(act)
+		if !ok || hours <= 0 {
+			continue
+		}
+		for _, d := range act.resourceDemands() {
+			l, ok := loads[d.key]
+			if !ok {
+				l = &ResourceLoad{Name: d.name, Resource: d.resource, Capacity: d.capacity}
+				loads[d.key] = l
+			}
+			l.Bookings = append(l.Bookings, ResourceBooking{Activity: act, From: info.ES, To: info.EF, Units: d.units})
+		}
+	}
+
+	var result []*ResourceLoad
+	for _, l := range loads {
+		l.Segments = loadSegments(l.Bookings)
+		result = append(result, l)
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// resourceKey normalizes a resource name for matching.
+func resourceKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// loadSegments sweeps the bookings and returns the intervals with a non-zero load.
+func loadSegments(bookings []ResourceBooking) []LoadSegment {
+	var times []float64
+	for _, b := range bookings {
+		times = append(times, b.From, b.To)
+	}
+	sort.Float64s(times)
+
+	var segments []LoadSegment
+	for i := 0; i+1 < len(times); i++ {
+		from, to := times[i], times[i+1]
+		if to-from <= slackEpsilon {
+			continue
+		}
+		seg := LoadSegment{From: from, To: to}
+		for _, b := range bookings {
+			if b.From <= from+slackEpsilon && b.To >= to-slackEpsilon {
+				seg.Units += b.Units
+				seg.Activities = append(seg.Activities, b.Activity)
+			}
+		}
+		if seg.Units > 0 {
+			segments = append(segments, seg)
+		}
+	}
+	return segments
+}
+
+// overAllocations returns the over-allocated intervals of the loads. Adjacent segments with the same
+// activities are merged.
+func overAllocations(loads []*ResourceLoad) []OverAllocation {
+	var result []OverAllocation
+	for _, l := range loads {
+		for _, seg := range l.Segments {
+			if seg.Units <= l.Capacity+slackEpsilon {
+				continue
+			}
+			if n := len(result); n > 0 {
+				last := &result[n-1]
+				if last.Resource == l.Name && last.To >= seg.From-slackEpsilon && sameActivities(last.Activities, seg.Activities) {
+					last.To = seg.To
+					if seg.Units > last.Load {
+						last.Load = seg.Units
+					}
+					continue
+				}
+			}
+			result = append(result, OverAllocation{
+				Resource:   l.Name,
+				From:       seg.From,
+				To:         seg.To,
+				Load:       seg.Units,
+				Capacity:   l.Capacity,
+				Activities: seg.Activities,
+			})
+		}
+	}
+	return result
+}
+
+// sameActivities returns true if a and b contain the same activities in the same order.
+func sameActivities(a, b []*Activity) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ResourceLoads returns the load of every resource using the project settings.
+func (p *Project) ResourceLoads() []*ResourceLoad {
+	return p.Root.resourceLoads(p.scheduleOptions())
+}
+
+// OverAllocations returns the intervals in which a resource is booked beyond its capacity, using the project settings.
+func (p *Project) OverAllocations() []OverAllocation {
+	return overAllocations(p.ResourceLoads())
+}
+
+// describe formats the over-allocation for a validation message. With a project start the interval is shown as dates.
+func (o OverAllocation) describe(p *Project) string {
+	var names []string
+	for _, act := range o.Activities {
+		names = append(names, act.Name)
+	}
+	when := fmt.Sprintf("hours %.0f-%.0f", o.From, o.To)
+	if p.Start != nil {
+		from, to := scheduleDates(*p.Start, p.Calendar, p.DurationConversion(), o.From, o.To)
+		when = fmt.Sprintf("%s - %s", from.String(), to.String())
+	}
+	return fmt.Sprintf("resource %q over-allocated %s: %g units booked, %g available (%s)",
+		o.Resource, when, o.Load, o.Capacity, strings.Join(names, ", "))
+}
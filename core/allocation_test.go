@@ -0,0 +1,95 @@
+package core
+
+import (
+	"explosio/core/resource"
+	"explosio/core/resource/human"
+	"explosio/core/unit"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newElectrician() *human.HumanResource {
+	return human.NewHumanResource("Electrician", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(200, "EUR"))
+}
+
+func TestOverAllocations_ParallelActivities(t *testing.T) {
+	// Plaster (4d) and Tiling (3d) run in parallel and both need the electrician.
+	root, plaster, tiling := buildLinkTestTree()
+	plaster.AddHumanResource(newElectrician())
+	tiling.AddHumanResource(human.NewHumanResource(" electrician", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(200, "EUR")))
+
+	over := root.OverAllocations()
+	if len(over) != 1 {
+		t.Fatalf("over-allocations = %v, want 1", over)
+	}
+	o := over[0]
+	if o.From != 0 || o.To != 72 || o.Load != 2 || o.Capacity != 1 {
+		t.Errorf("over-allocation = %+v, want hours 0-72, load 2 of 1", o)
+	}
+	if len(o.Activities) != 2 {
+		t.Errorf("activities = %v, want Plaster and Tiling", o.Activities)
+	}
+
+	// In sequence there is no conflict.
+	tiling.AddDependsOn(plaster)
+	if over := root.OverAllocations(); len(over) != 0 {
+		t.Errorf("over-allocations in sequence = %v, want none", over)
+	}
+}
+
+func TestOverAllocations_PoolCapacityAndUnits(t *testing.T) {
+	root, plaster, tiling := buildLinkTestTree()
+	proj := NewProject(root)
+	crew := proj.AddResource(resource.NewResource("Crew", "Mason", *unit.NewPrice(30, "EUR")))
+	plaster.Assign(crew, 0.5, 16)
+	tiling.Assign(crew, 0.5, 12)
+	if over := proj.OverAllocations(); len(over) != 0 {
+		t.Errorf("half-time assignments = %v, want none", over)
+	}
+
+	tiling.Assign(crew, 1, 12)
+	over := proj.OverAllocations()
+	if len(over) != 1 || over[0].Load != 2 || over[0].To != 72 {
+		t.Fatalf("over-allocations = %+v, want load 2 over hours 0-72", over)
+	}
+	crew.MaxUnits = 2
+	if over := proj.OverAllocations(); len(over) != 0 {
+		t.Errorf("with 2 units available = %v, want none", over)
+	}
+}
+
+func TestResourceLoads_Segments(t *testing.T) {
+	root, plaster, tiling := buildLinkTestTree()
+	plaster.AddHumanResource(newElectrician())
+	tiling.AddHumanResource(newElectrician())
+
+	loads := root.ResourceLoads()
+	if len(loads) != 1 {
+		t.Fatalf("loads = %d resources, want 1", len(loads))
+	}
+	segs := loads[0].Segments
+	if len(segs) != 2 || segs[0].Units != 2 || segs[1].From != 72 || segs[1].To != 96 || segs[1].Units != 1 {
+		t.Errorf("segments = %+v, want [0-72: 2] [72-96: 1]", segs)
+	}
+}
+
+func TestProject_Validate_OverAllocation(t *testing.T) {
+	root, plaster, tiling := buildLinkTestTree()
+	plaster.AddHumanResource(newElectrician())
+	tiling.AddHumanResource(newElectrician())
+	proj := NewProject(root)
+	start := unit.NewDate(2026, time.March, 2)
+	proj.Start = &start
+
+	r := proj.Validate()
+	var found bool
+	for _, w := range r.Warnings {
+		if strings.Contains(w.Message, `"Electrician" over-allocated 2026-03-02 - 2026-03-05`) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected an over-allocation warning with dates, got %v", r.Warnings)
+	}
+}
@@ -0,0 +1,216 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"explosio/core/unit"
+)
+
+// BaselineEntry is the planned start, finish and cost of an activity when the baseline was saved.
+// Dates and cost are rolled up through the subtree: a parent spans its descendants and costs their total.
+type BaselineEntry struct {
+	ActivityID string    `json:"activity" yaml:"activity"`
+	Start      unit.Date `json:"start" yaml:"start"`
+	Finish     unit.Date `json:"finish" yaml:"finish"`
+	Cost       float64   `json:"cost" yaml:"cost"`
+}
+
+// Baseline is a named snapshot of the planned schedule and costs, stored in the project file.
+// Activities are referenced by ID.
+type Baseline struct {
+	Name       string          `json:"name" yaml:"name"`
+	Saved      unit.Date       `json:"saved" yaml:"saved"`
+	Start      unit.Date       `json:"start" yaml:"start"` // Project start used for the snapshot
+	Currency   string          `json:"currency,omitempty" yaml:"currency,omitempty"`
+	Activities []BaselineEntry `json:"activities" yaml:"activities"`
+}
+
+// rolledUp holds the start, finish and cost of an activity rolled up through its subtree.
+type rolledUp struct {
+	start, finish unit.Date
+	cost          float64
+}
+
+// rollUp returns the rolled-up dates and cost of every activity in the tree for the given schedule.
+// Dates are whole days, as stored in the project file; costs are converted with c.
+func (a *Activity) rollUp(schedule map[*Activity]Schedule, c *CurrencyConversion, out map[*Activity]rolledUp) (rolledUp, error) {
+	own, err := a.ownMoneyBreakdownIn(c)
+	if err != nil {
+		return rolledUp{}, err
+	}
+	total := own.Total()
+	r := rolledUp{cost: total.Float64()}
+	if s, ok := schedule[a]; ok {
+		r.start, r.finish = s.StartDate.StartOfDay(), s.EndDate.StartOfDay()
+	}
+	for _, child := range a.Activities {
+		cr, err := child.rollUp(schedule, c, out)
+		if err != nil {
+			return rolledUp{}, err
+		}
+		if cr.start.Time.Before(r.start.Time) {
+			r.start = cr.start
+		}
+		if cr.finish.Time.After(r.finish.Time) {
+			r.finish = cr.finish
+		}
+		r.cost += cr.cost
+	}
+	out[a] = r
+	return r, nil
+}
+
+// SetBaseline saves the current schedule (from projectStart) and costs as the baseline with the given name,
+// replacing an existing baseline with that name. Activities without an ID are given one. Costs are stored in the
+// reporting currency, converted with the exchange rates effective on the conversion date; it fails if a currency
+// has no exchange rate on that date, leaving the baselines unchanged.
+func (p *Project) SetBaseline(name string, projectStart unit.Date) (*Baseline, error) {
+	c := p.CurrencyConversion("", p.ConversionDate())
+	rolled := make(map[*Activity]rolledUp)
+	if _, err := p.Root.rollUp(p.ComputeSchedule(projectStart), &c, rolled); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	b := &Baseline{
+		Name:     name,
+		Saved:    unit.NewDate(now.Year(), now.Month(), now.Day()),
+		Start:    projectStart,
+		Currency: c.Currency,
+	}
+	for _, act := range p.Root.GetActivities() {
+		if act.ID == "" {
+			act.ID = NewActivityID()
+		}
+		r := rolled[act]
+		b.Activities = append(b.Activities, BaselineEntry{ActivityID: act.ID, Start: r.start, Finish: r.finish, Cost: r.cost})
+	}
+
+	for i, existing := range p.Baselines {
+		if existing.Name == name {
+			p.Baselines[i] = b
+			return b, nil
+		}
+	}
+	p.Baselines = append(p.Baselines, b)
+	return b, nil
+}
+
+// FindBaseline returns the baseline with the given name, or nil if not found.
+func (p *Project) FindBaseline(name string) *Baseline {
+	for _, b := range p.Baselines {
+		if b.Name == name {
+			return b
+		}
+	}
+	return nil
+}
+
+// RemoveBaseline deletes the baseline with the given name. Returns false if not found.
+func (p *Project) RemoveBaseline(name string) bool {
+	for i, b := range p.Baselines {
+		if b.Name == name {
+			p.Baselines = append(p.Baselines[:i], p.Baselines[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// ActivityVariance compares an activity with its baseline entry. Slips are in calendar days
+// (positive = later than planned); CostVariance is current minus baseline cost (positive = overrun).
+type ActivityVariance struct {
+	Activity       *Activity
+	Depth          int  // Depth in the tree (root = 0)
+	InBaseline     bool // False for activities added after the baseline was saved
+	BaselineStart  unit.Date
+	BaselineFinish unit.Date
+	Start          unit.Date
+	Finish         unit.Date
+	StartSlip      float64
+	FinishSlip     float64
+	BaselineCost   float64
+	Cost           float64
+	CostVariance   float64
+}
+
+// VarianceReport is the comparison of the current plan with a baseline.
+type VarianceReport struct {
+	Baseline   *Baseline
+	Activities []ActivityVariance // In tree order
+	Removed    []BaselineEntry    // Baseline entries whose activity is no longer in the tree
+}
+
+// Variance compares the current schedule (from projectStart) and costs with the named baseline. Current costs are
+// converted into the baseline currency (the reporting currency for baselines saved without one) with the exchange
+// rates effective on the conversion date.
+func (p *Project) Variance(name string, projectStart unit.Date) (*VarianceReport, error) {
+	b := p.FindBaseline(name)
+	if b == nil {
+		return nil, fmt.Errorf("baseline %q not found", name)
+	}
+	entries := make(map[string]BaselineEntry)
+	for _, e := range b.Activities {
+		entries[e.ActivityID] = e
+	}
+	c := p.CurrencyConversion(b.Currency, p.ConversionDate())
+	rolled := make(map[*Activity]rolledUp)
+	if _, err := p.Root.rollUp(p.ComputeSchedule(projectStart), &c, rolled); err != nil {
+		return nil, err
+	}
+
+	report := &VarianceReport{Baseline: b}
+	present := make(map[string]bool)
+	var walk func(a *Activity, depth int)
+	walk = func(a *Activity, depth int) {
+		r := rolled[a]
+		v := ActivityVariance{Activity: a, Depth: depth, Start: r.start, Finish: r.finish, Cost: r.cost}
+		if e, ok := entries[a.ID]; ok && a.ID != "" {
+			present[a.ID] = true
+			v.InBaseline = true
+			v.BaselineStart, v.BaselineFinish, v.BaselineCost = e.Start, e.Finish, e.Cost
+			v.StartSlip = r.start.Time.Sub(e.Start.Time).Hours() / 24
+			v.FinishSlip = r.finish.Time.Sub(e.Finish.Time).Hours() / 24
+			v.CostVariance = r.cost - e.Cost
+		}
+		report.Activities = append(report.Activities, v)
+		for _, child := range a.Activities {
+			walk(child, depth+1)
+		}
+	}
+	walk(p.Root, 0)
+	for _, e := range b.Activities {
+		if !present[e.ActivityID] {
+			report.Removed = append(report.Removed, e)
+		}
+	}
+	return report, nil
+}
+
+// PrintVariance prints the variance report: start and finish slip in days and cost overrun per activity,
+// indented by tree depth. Activities not in the baseline are marked "new".
+func PrintVariance(r *VarianceReport) {
+	fmt.Println("--------------------------------")
+	fmt.Printf("   Variance vs baseline %q (saved %s)\n", r.Baseline.Name, r.Baseline.Saved.String())
+	fmt.Println("--------------------------------")
+	fmt.Printf("%-28s %10s %10s %8s %8s %12s %12s %12s\n", "Activity", "Start", "Finish", "Start Δ", "Finish Δ", "Baseline", "Current", "Overrun")
+	fmt.Println(strings.Repeat("-", 28+10*2+8*2+12*3+7))
+	for _, v := range r.Activities {
+		name := strings.Repeat("  ", v.Depth) + v.Activity.Name
+		if len(name) > 28 {
+			name = name[:25] + "..."
+		}
+		if !v.InBaseline {
+			fmt.Printf("%-28s %10s %10s %8s %8s %12s %12.2f %12s\n", name,
+				v.Start.String(), v.Finish.String(), "new", "new", "-", v.Cost, "-")
+			continue
+		}
+		fmt.Printf("%-28s %10s %10s %+7.1fd %+7.1fd %12.2f %12.2f %+12.2f\n", name,
+			v.Start.String(), v.Finish.String(), v.StartSlip, v.FinishSlip, v.BaselineCost, v.Cost, v.CostVariance)
+	}
+	if len(r.Removed) > 0 {
+		fmt.Printf("%d baseline activities are no longer in the project\n", len(r.Removed))
+	}
+}
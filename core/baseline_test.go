@@ -0,0 +1,109 @@
+package core
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"explosio/core/unit"
+)
+
+func TestVariance_SlipAndOverrunWithRollUp(t *testing.T) {
+	root, plaster, tiling := buildLinkTestTree()
+	tiling.AddDependsOn(plaster)
+	tiling.Price.Value = 1000
+	proj := NewProject(root)
+	start := unit.NewDate(2026, time.March, 2)
+	if _, err := proj.SetBaseline("contract", start); err != nil {
+		t.Fatal(err)
+	}
+
+	// Plaster takes 2 days longer, Tiling costs 300 more, a new activity is added.
+	plaster.Duration.Value = 6
+	tiling.Price.Value = 1300
+	root.AddActivity(NewActivity("Clean up", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(50, "EUR")))
+
+	r, err := proj.Variance("contract", start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Activities) != 4 || r.Activities[3].InBaseline {
+		t.Fatalf("activities = %d, want 4 with the new one not in the baseline", len(r.Activities))
+	}
+	byName := make(map[string]ActivityVariance)
+	for _, v := range r.Activities {
+		byName[v.Activity.Name] = v
+	}
+	if v := byName["Plaster"]; v.StartSlip != 0 || v.FinishSlip != 2 {
+		t.Errorf("Plaster slip = %v/%v days, want 0/2", v.StartSlip, v.FinishSlip)
+	}
+	if v := byName["Tiling"]; v.StartSlip != 2 || v.FinishSlip != 2 || v.CostVariance != 300 {
+		t.Errorf("Tiling slip = %v/%v, overrun %v, want 2/2 and 300", v.StartSlip, v.FinishSlip, v.CostVariance)
+	}
+	// The root rolls up the finish of Tiling and the cost of all children, including the new one.
+	if v := byName["Root"]; v.FinishSlip != 2 || v.CostVariance != 350 || v.Depth != 0 || byName["Tiling"].Depth != 1 {
+		t.Errorf("Root slip = %v, overrun %v, want 2 and 350", v.FinishSlip, v.CostVariance)
+	}
+
+	if _, err := proj.Variance("missing", start); err == nil {
+		t.Error("expected an error for an unknown baseline")
+	}
+}
+
+func TestBaseline_ReplaceAndRoundTrip(t *testing.T) {
+	root, plaster, _ := buildLinkTestTree()
+	proj := NewProject(root)
+	start := unit.NewDate(2026, time.March, 2)
+	proj.SetBaseline("v1", start)
+	plaster.Duration.Value = 5
+	proj.SetBaseline("v1", start)
+	proj.SetBaseline("v2", start.AddDays(7))
+	if len(proj.Baselines) != 2 {
+		t.Fatalf("baselines = %d, want 2 (v1 replaced)", len(proj.Baselines))
+	}
+
+	var buf bytes.Buffer
+	if err := proj.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ReadJSON(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := loaded.Variance("v1", start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range r.Activities {
+		if !v.InBaseline || v.StartSlip != 0 || v.FinishSlip != 0 || v.CostVariance != 0 {
+			t.Errorf("%s: variance %+v, want none against the replaced baseline", v.Activity.Name, v)
+		}
+	}
+	if !loaded.RemoveBaseline("v2") || loaded.FindBaseline("v2") != nil {
+		t.Error("RemoveBaseline should delete v2")
+	}
+}
+
+func TestBaseline_ConvertsCosts(t *testing.T) {
+	proj, _ := buildCurrencyProject()
+	start := unit.NewDate(2026, time.March, 2)
+	b, err := proj.SetBaseline("contract", start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// June rate 1.10: tiles 200 CHF = 220 EUR, labor 200, Tiling 100 EUR.
+	if b.Currency != "EUR" || math.Abs(b.Activities[0].Cost-520) > 1e-9 {
+		t.Fatalf("baseline = %v %s, want 520 EUR", b.Activities[0].Cost, b.Currency)
+	}
+
+	// Reporting in CHF afterwards: the variance still compares EUR with EUR.
+	proj.Currency = "CHF"
+	r, err := proj.Variance("contract", start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := r.Activities[0]; math.Abs(v.Cost-520) > 1e-9 || math.Abs(v.CostVariance) > 1e-9 {
+		t.Errorf("root cost = %v, variance %v, want 520 EUR and no variance", v.Cost, v.CostVariance)
+	}
+}
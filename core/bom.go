@@ -0,0 +1,265 @@
+package core
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"explosio/core/material"
+	"explosio/core/unit"
+)
+
+// BOMLine is a material of the bill of materials: the same material used by several activities on one line.
+// Quantity is the net quantity used and Purchased the quantity bought, both in Unit (pieces for countable
+// materials): waste is added per occurrence, then the merged quantity is rounded up to whole packs once.
+// Cost is the price of the purchased quantity in the BOM currency.
+type BOMLine struct {
+	Code       string
+	Name       string
+	Quantity   float64
+	Purchased  float64
+	Unit       unit.MeasurableUnit
+	Cost       unit.Money
+	Suppliers  []string
+	Activities []string // Activities using the material, in tree order
+}
+
+// UnitCost returns the average cost per purchased unit of the line (0 if nothing is purchased).
+func (l *BOMLine) UnitCost() unit.Money {
+	if l.Purchased == 0 {
+		return unit.Money{Currency: l.Cost.Currency}
+	}
+	return l.Cost.Div(l.Purchased, unit.RoundHalfEven)
+}
+
+// BOM is the bill of materials of a tree: identical materials merged, sorted by name.
+type BOM struct {
+	Currency string
+	Lines    []*BOMLine
+}
+
+// bomItem is a material occurrence before merging: gross is the quantity with waste but not yet rounded to packs
+// (pack, in the item unit), and cost the price of the gross quantity.
+type bomItem struct {
+	code, name, supplier string
+	quantity, gross      float64
+	pack                 float64
+	unit                 unit.MeasurableUnit
+	cost                 unit.Money
+}
+
+// bomTotals accumulates the gross quantity and cost of a line before pack rounding.
+type bomTotals struct {
+	gross, pack float64
+	cost        unit.Money
+}
+
+// withWaste returns q plus the waste percentage.
+func withWaste(q, wastePercent float64) float64 {
+	if wastePercent > 0 {
+		q *= 1 + wastePercent/100
+	}
+	return q
+}
+
+// bomItems returns the materials of the activity's own scope. Complex materials are expanded into their
+// measurable component (purchased units × component quantity, already rounded to the complex packs);
+// the complex price itself is a line of pieces if not zero.
+func (a *Activity) bomItems() []bomItem {
+	var items []bomItem
+	for _, m := range a.CountableMaterials {
+		gross := withWaste(float64(m.Quantity), m.WastePercent)
+		items = append(items, bomItem{m.Code, m.Name, m.Supplier, float64(m.Quantity), gross, float64(m.PackSize),
+			unit.UnitPiece, m.Price.Money().Mul(gross, unit.RoundHalfEven)})
+	}
+	for _, m := range a.MeasurableMaterials {
+		gross := withWaste(m.Quantity.Value, m.WastePercent)
+		items = append(items, bomItem{m.Code, m.Name, m.Supplier, m.Quantity.Value, gross, m.PackSize, m.Quantity.Unit,
+			m.Price.Money().Mul(m.InPriceUnit(gross), unit.RoundHalfEven)})
+	}
+	for _, c := range a.ComplexMaterials {
+		if c.Price.Value != 0 || c.MeasurableMaterial == nil {
+			items = append(items, bomItem{c.Code, c.Name, c.Supplier, 1, 1, 0, unit.UnitPiece, c.Price.Money()})
+		}
+		if m := c.MeasurableMaterial; m != nil {
+			// Waste and packs of the complex material apply to its units; the content is priced net.
+			purchased := c.PurchaseQuantity()
+			items = append(items, bomItem{m.Code, m.Name, m.Supplier, m.Quantity.Value * float64(c.UnitQuantity),
+				m.Quantity.Value * float64(purchased), 0, m.Quantity.Unit, m.NetMoney().MulInt(int64(purchased))})
+		}
+	}
+	return items
+}
+
+// bomKey identifies identical materials: same code (or same name, ignoring case, if there is no code)
+// and same dimension, so that quantities can be converted to one unit.
+func bomKey(it bomItem) string {
+	id := "name:" + strings.ToLower(strings.TrimSpace(it.name))
+	if it.code != "" {
+		id = "code:" + it.code
+	}
+	dim := string(it.unit.Dimension())
+	if dim == "" {
+		dim = "unit:" + string(it.unit)
+	}
+	return id + "|" + dim
+}
+
+// BOM returns the bill of materials of the tree: identical materials are merged across activities,
+// quantities converted to the unit of the first occurrence and costs converted into the reporting currency.
+// Packs are rounded once on the merged quantity (pack size of the first occurrence that has one), so two
+// activities using 40 screws each in boxes of 100 buy one box; the extra quantity is priced at the average
+// unit cost of the line. It fails if a currency has no exchange rate on the conversion date.
+func (a *Activity) BOM(c CurrencyConversion) (*BOM, error) {
+	bom := &BOM{Currency: c.Currency}
+	lines := make(map[string]*BOMLine)
+	totals := make(map[*BOMLine]*bomTotals)
+	for _, act := range a.GetActivities() {
+		for _, it := range act.bomItems() {
+			r, err := c.Rates.Rate(it.cost.Currency, c.Currency, c.Date)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", act.Name, err)
+			}
+			cost := it.cost.Mul(r, unit.RoundHalfEven)
+			cost.Currency = c.Currency
+
+			key := bomKey(it)
+			line, ok := lines[key]
+			if !ok {
+				line = &BOMLine{Code: it.code, Name: it.name, Unit: it.unit, Cost: unit.Money{Currency: c.Currency}}
+				lines[key] = line
+				totals[line] = &bomTotals{cost: unit.Money{Currency: c.Currency}}
+				bom.Lines = append(bom.Lines, line)
+			}
+			q, err := unit.ConvertUnit(it.quantity, it.unit, line.Unit)
+			if err != nil {
+				return nil, fmt.Errorf("%s: material %q: %w", act.Name, it.name, err)
+			}
+			gross, _ := unit.ConvertUnit(it.gross, it.unit, line.Unit)
+			t := totals[line]
+			if t.pack == 0 && it.pack > 0 {
+				t.pack, _ = unit.ConvertUnit(it.pack, it.unit, line.Unit)
+			}
+			line.Quantity += q
+			t.gross += gross
+			t.cost = t.cost.Add(cost)
+			line.Suppliers = appendUnique(line.Suppliers, it.supplier)
+			line.Activities = appendUnique(line.Activities, act.Name)
+		}
+	}
+	for _, line := range bom.Lines {
+		t := totals[line]
+		line.Purchased = material.RoundPurchase(t.gross, t.pack, line.Unit.Dimension() == unit.DimensionCount)
+		line.Cost = t.cost
+		if t.gross > 0 && line.Purchased != t.gross {
+			line.Cost = t.cost.Mul(line.Purchased/t.gross, unit.RoundHalfEven)
+		}
+	}
+	sort.SliceStable(bom.Lines, func(i, j int) bool {
+		return strings.ToLower(bom.Lines[i].Name) < strings.ToLower(bom.Lines[j].Name)
+	})
+	return bom, nil
+}
+
+// appendUnique appends s to list if it is not empty and not already present.
+func appendUnique(list []string, s string) []string {
+	if s == "" {
+		return list
+	}
+	for _, x := range list {
+		if x == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+// BOM returns the bill of materials of the project in currency (the reporting currency if empty)
+// with the exchange rates effective on the date.
+func (p *Project) BOM(currency string, on unit.Date) (*BOM, error) {
+	return p.Root.BOM(p.CurrencyConversion(currency, on))
+}
+
+// Total returns the total cost of the bill of materials.
+func (b *BOM) Total() unit.Money {
+	total := unit.Money{Currency: b.Currency}
+	for _, l := range b.Lines {
+		total = total.Add(l.Cost)
+	}
+	return total
+}
+
+// formatQuantity formats a quantity without trailing zeros (e.g. 12, 2.5, 0.125).
+func formatQuantity(q float64) string {
+	return strconv.FormatFloat(q, 'f', -1, 64)
+}
+
+// PrintBOM prints the bill of materials as a table, with costs rounded to the currency minor unit.
+func PrintBOM(b *BOM) {
+	fmt.Println("--------------------------------")
+	fmt.Printf("   Bill of Materials (%s)\n", b.Currency)
+	fmt.Println("--------------------------------")
+	fmt.Printf("%-12s %-28s %10s %10s %-5s %12s %12s  %s\n", "Code", "Material", "Net qty", "Purchased", "Unit", "Unit cost", "Cost", "Supplier")
+	fmt.Println(strings.Repeat("-", 110))
+	minor := unit.MinorUnits(b.Currency)
+	for _, l := range b.Lines {
+		row := fmt.Sprintf("%-12s %-28s %10s %10s %-5s %12s %12s  %s", l.Code, l.Name, formatQuantity(l.Quantity), formatQuantity(l.Purchased), l.Unit,
+			l.UnitCost().Decimal(4), l.Cost.Decimal(minor), strings.Join(l.Suppliers, ", "))
+		fmt.Println(strings.TrimRight(row, " "))
+	}
+	fmt.Println(strings.Repeat("-", 110))
+	fmt.Printf("%-12s %-28s %10s %10s %-5s %12s %12s\n", "", "Total", "", "", "", "", b.Total().Decimal(minor))
+}
+
+// WriteBOMCSV writes the bill of materials as CSV: code, name, quantity, purchased, unit, unit_cost, cost, currency, suppliers, activities.
+// Suppliers and activities are separated by ";".
+func WriteBOMCSV(out io.Writer, b *BOM) error {
+	cw := csv.NewWriter(out)
+	if err := cw.Write([]string{"code", "name", "quantity", "purchased", "unit", "unit_cost", "cost", "currency", "suppliers", "activities"}); err != nil {
+		return err
+	}
+	minor := unit.MinorUnits(b.Currency)
+	for _, l := range b.Lines {
+		row := []string{l.Code, l.Name, formatQuantity(l.Quantity), formatQuantity(l.Purchased), string(l.Unit), l.UnitCost().Decimal(4), l.Cost.Decimal(minor),
+			b.Currency, strings.Join(l.Suppliers, ";"), strings.Join(l.Activities, ";")}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// bomLineJSON is the JSON form of a BOMLine: amounts are numbers in the BOM currency.
+type bomLineJSON struct {
+	Code       string              `json:"code,omitempty"`
+	Name       string              `json:"name"`
+	Quantity   float64             `json:"quantity"`
+	Purchased  float64             `json:"purchased"`
+	Unit       unit.MeasurableUnit `json:"unit"`
+	UnitCost   float64             `json:"unitCost"`
+	Cost       float64             `json:"cost"`
+	Suppliers  []string            `json:"suppliers,omitempty"`
+	Activities []string            `json:"activities"`
+}
+
+// WriteBOMJSON writes the bill of materials as indented JSON with the currency, the lines and the total.
+func WriteBOMJSON(out io.Writer, b *BOM) error {
+	doc := struct {
+		Currency string        `json:"currency"`
+		Lines    []bomLineJSON `json:"lines"`
+		Total    float64       `json:"total"`
+	}{Currency: b.Currency, Lines: []bomLineJSON{}}
+	for _, l := range b.Lines {
+		doc.Lines = append(doc.Lines, bomLineJSON{l.Code, l.Name, l.Quantity, l.Purchased, l.Unit, l.UnitCost().Float64(), l.Cost.Float64(),
+			l.Suppliers, l.Activities})
+	}
+	doc.Total = b.Total().Float64()
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
@@ -0,0 +1,160 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"explosio/core/material"
+	"explosio/core/unit"
+)
+
+// buildBOMProject returns a project where Plaster and Tiling both use cement (in kg and in g),
+// screws with the same code under different names, and a complex material of 5 pipes of 2 m.
+func buildBOMProject() *Project {
+	root, plaster, tiling := buildLinkTestTree()
+	plaster.AddMeasurableMaterial(material.NewMeasurableMaterial("Cement", "", *unit.NewPrice(0.5, "EUR"), *unit.NewMeasurableQuantity(20, unit.UnitKilogram)))
+	tiling.AddMeasurableMaterial(material.NewMeasurableMaterial("cement", "", *unit.NewPrice(0.0005, "EUR"), *unit.NewMeasurableQuantity(2500, unit.UnitGram)))
+
+	screws := material.NewCountableMaterial("Screws 4x40", "", *unit.NewPrice(0.1, "EUR"), 100)
+	screws.Code = "SCR-440"
+	screws.Supplier = "Acme"
+	tiling.AddCountableMaterial(screws)
+	moreScrews := material.NewCountableMaterial("Wood screws", "", *unit.NewPrice(0.2, "CHF"), 50)
+	moreScrews.Code = "SCR-440"
+	moreScrews.Supplier = "Brico"
+	plaster.AddCountableMaterial(moreScrews)
+
+	pipe := material.NewMeasurableMaterial("Pipe", "", *unit.NewPrice(3, "EUR"), *unit.NewMeasurableQuantity(2, unit.UnitMeter))
+	tiling.AddComplexMaterial(material.NewComplexMaterial("Pipe kit", "", *unit.NewPrice(0, "EUR"), 5, pipe))
+
+	proj := NewProject(root)
+	proj.ExchangeRates = unit.ExchangeRates{{From: "CHF", To: "EUR", Rate: 1.1, Date: unit.NewDate(2026, time.January, 1)}}
+	return proj
+}
+
+func TestProject_BOM(t *testing.T) {
+	bom, err := buildBOMProject().BOM("", unit.NewDate(2026, time.March, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bom.Lines) != 3 {
+		t.Fatalf("lines = %d, want cement, pipe and screws", len(bom.Lines))
+	}
+	cement, pipe, screws := bom.Lines[0], bom.Lines[1], bom.Lines[2]
+	// 20 kg + 2500 g, in the unit of the first occurrence.
+	if cement.Name != "Cement" || cement.Quantity != 22.5 || cement.Unit != unit.UnitKilogram || cement.Cost.Decimal(2) != "11.25" {
+		t.Errorf("cement = %+v, want 22.5 kg for 11.25 EUR", cement)
+	}
+	if len(cement.Activities) != 2 {
+		t.Errorf("cement activities = %v, want Plaster and Tiling", cement.Activities)
+	}
+	// The complex material is expanded: 5 × 2 m of pipe; its zero price adds no line.
+	if pipe.Name != "Pipe" || pipe.Quantity != 10 || pipe.Unit != unit.UnitMeter || pipe.Cost.Decimal(2) != "30.00" {
+		t.Errorf("pipe = %+v, want 10 m for 30 EUR", pipe)
+	}
+	// Same code: merged although the names differ; CHF converted at 1.1.
+	if screws.Code != "SCR-440" || screws.Quantity != 150 || screws.Unit != unit.UnitPiece || screws.Cost.Decimal(2) != "21.00" {
+		t.Errorf("screws = %+v, want 150 pc for 21 EUR", screws)
+	}
+	if strings.Join(screws.Suppliers, ",") != "Brico,Acme" {
+		t.Errorf("suppliers = %v, want Brico, Acme", screws.Suppliers)
+	}
+	if got := bom.Total(); got.Decimal(2) != "62.25" || got.Currency != "EUR" {
+		t.Errorf("total = %v, want 62.25 EUR", got)
+	}
+}
+
+func TestProject_BOMMissingRate(t *testing.T) {
+	proj := buildBOMProject()
+	proj.ExchangeRates = nil
+	if _, err := proj.BOM("", unit.NewDate(2026, time.March, 2)); err == nil {
+		t.Error("expected an error for CHF without an exchange rate")
+	}
+}
+
+func TestWriteBOM(t *testing.T) {
+	bom, err := buildBOMProject().BOM("", unit.NewDate(2026, time.March, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var csvBuf bytes.Buffer
+	if err := WriteBOMCSV(&csvBuf, bom); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(csvBuf.String()), "\n")
+	if len(lines) != 4 || lines[1] != ",Cement,22.5,22.5,kg,0.5000,11.25,EUR,,Plaster;Tiling" {
+		t.Errorf("CSV = %q", csvBuf.String())
+	}
+
+	var jsonBuf bytes.Buffer
+	if err := WriteBOMJSON(&jsonBuf, bom); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Currency string
+		Lines    []struct {
+			Code     string
+			Quantity float64
+			Cost     float64
+		}
+		Total float64
+	}
+	if err := json.Unmarshal(jsonBuf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Currency != "EUR" || len(doc.Lines) != 3 || doc.Lines[2].Code != "SCR-440" || doc.Total != 62.25 {
+		t.Errorf("JSON = %s", jsonBuf.String())
+	}
+}
+
+func TestProject_BOMPurchasedQuantity(t *testing.T) {
+	root, plaster, tiling := buildLinkTestTree()
+	for _, act := range []*Activity{plaster, tiling} {
+		screws := material.NewCountableMaterial("Screws", "", *unit.NewPrice(0.1, "EUR"), 40)
+		screws.PackSize = 100
+		act.AddCountableMaterial(screws)
+	}
+	bom, err := NewProject(root).BOM("", unit.Date{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The merged 80 pieces fit in one box: packs are rounded once per line, not per activity.
+	l := bom.Lines[0]
+	if l.Quantity != 80 || l.Purchased != 100 || l.Cost.Decimal(2) != "10.00" || l.UnitCost().Decimal(2) != "0.10" {
+		t.Errorf("screws = %+v, want 80 used, 100 purchased for 10 EUR", l)
+	}
+
+	// With 30% waste each activity needs 52 pieces: 104 in total, two boxes.
+	for _, act := range []*Activity{plaster, tiling} {
+		act.CountableMaterials[0].WastePercent = 30
+	}
+	bom, err = NewProject(root).BOM("", unit.Date{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l = bom.Lines[0]
+	if l.Quantity != 80 || l.Purchased != 200 || l.Cost.Decimal(2) != "20.00" {
+		t.Errorf("screws with waste = %+v, want 80 used, 200 purchased for 20 EUR", l)
+	}
+}
+
+func TestProject_BOMComplexWasteAppliedOnce(t *testing.T) {
+	root, plaster, _ := buildLinkTestTree()
+	pipe := material.NewMeasurableMaterial("Pipe", "", *unit.NewPrice(3, "EUR"), *unit.NewMeasurableQuantity(2, unit.UnitMeter))
+	pipe.WastePercent = 10
+	kit := material.NewComplexMaterial("Pipe kit", "", *unit.NewPrice(0, "EUR"), 10, pipe)
+	kit.WastePercent = 10
+	plaster.AddComplexMaterial(kit)
+	bom, err := NewProject(root).BOM("", unit.Date{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 10 kits + 10% = 11 kits of 2 m: the component waste is not added again.
+	l := bom.Lines[0]
+	if l.Quantity != 20 || l.Purchased != 22 || l.Cost.Decimal(2) != "66.00" || l.Cost.Decimal(2) != kit.CalculateMoney().Decimal(2) {
+		t.Errorf("pipe = %+v, want 20 m used, 22 m purchased for 66 EUR like the material price", l)
+	}
+}
@@ -1,8 +1,10 @@
 package core
 
+import "explosio/core/unit"
+
 // This file contains calculation methods on Activity (price, duration, quantity, critical path).
 
-// pricers returns all direct price contributors (materials, sub-activities, human resources, assets).
+// pricers returns all direct price contributors (materials, sub-activities, human resources, assets, assignments).
 func (a *Activity) pricers() []Pricer {
 	var p []Pricer
 	for _, m := range a.ComplexMaterials {
@@ -23,14 +25,23 @@ func (a *Activity) pricers() []Pricer {
 	for _, as := range a.Assets {
 		p = append(p, as)
 	}
+	for _, as := range a.Assignments {
+		p = append(p, as)
+	}
 	return p
 }
 
 // CalculatePrice returns the total price (activity plus all materials and sub-activities).
 func (a *Activity) CalculatePrice() float64 {
-	price := a.Price.Value
+	return a.CalculateMoney().Float64()
+}
+
+// CalculateMoney returns the exact total price: amounts are summed without rounding error.
+// Like CalculatePrice, amounts are added as they are, whatever their currency (see CalculatePriceIn).
+func (a *Activity) CalculateMoney() unit.Money {
+	price := a.Price.Money()
 	for _, p := range a.pricers() {
-		price += p.CalculatePrice()
+		price = price.Add(p.CalculateMoney())
 	}
 	return price
 }
@@ -44,22 +55,46 @@ func (a *Activity) CalculateDuration() float64 {
 	return duration
 }
 
-// calculateDurationHours returns the total subtree duration in hours (for filtering).
-func (a *Activity) calculateDurationHours() float64 {
-	hours := a.Duration.ToHours()
+// calculateDurationHours returns the total subtree duration in hours (for filtering), using the given conversion.
+func (a *Activity) calculateDurationHours(conv unit.DurationConversion) float64 {
+	hours := a.Duration.ToHoursWith(conv)
 	for _, child := range a.Activities {
-		hours += child.calculateDurationHours()
+		hours += child.calculateDurationHours(conv)
 	}
 	return hours
 }
 
-// CalculateCriticalPath returns the critical path. With explicit DependsOn, uses full CPM; otherwise uses tree-based longest path.
+// CriticalPathMode selects which activities form the critical path.
+type CriticalPathMode int
+
+const (
+	CriticalPathZeroFloat   CriticalPathMode = iota // Activities with total float <= 0 (default)
+	CriticalPathLowestFloat                         // Activities with the lowest total float ("most critical path")
+)
+
+// CalculateCriticalPath returns the critical path. With explicit DependsOn or constraints, uses full CPM;
+// otherwise uses tree-based longest path.
+// Durations are converted with calendar time (1 day = 24h); see Project.CalculateCriticalPath for the project policy.
 func (a *Activity) CalculateCriticalPath() []*Activity {
-	if a.hasExplicitDependencies() {
-		m, _ := a.cpmForwardBackward()
-		return criticalPathFromSlack(m)
+	return a.calculateCriticalPath(defaultScheduleOptions(), CriticalPathZeroFloat)
+}
+
+// CalculateCriticalPathWithMode returns the critical path selected by mode. CriticalPathLowestFloat is useful
+// when no activity has zero float (e.g. the deadline is later than the computed end, or it cannot be met).
+func (a *Activity) CalculateCriticalPathWithMode(mode CriticalPathMode) []*Activity {
+	return a.calculateCriticalPath(defaultScheduleOptions(), mode)
+}
+
+func (a *Activity) calculateCriticalPath(opts scheduleOptions, mode CriticalPathMode) []*Activity {
+	if mode == CriticalPathLowestFloat {
+		m, _ := a.calculateSlack(opts)
+		return criticalPathFromSlack(m, lowestFloat(m))
+	}
+	if a.usesFullCPM(opts) {
+		m, _ := a.cpmForwardBackward(opts)
+		return criticalPathFromSlack(m, 0)
 	}
-	path, _ := a.criticalPathAndDuration()
+	path, _ := a.criticalPathAndDuration(opts)
 	return path
 }
 
@@ -67,69 +102,115 @@ func (a *Activity) CalculateCriticalPath() []*Activity {
 type CostBreakdown struct {
 	Activities float64 // Direct activity prices (own Price.Value)
 	Materials  float64 // Complex + countable + measurable materials
-	Human      float64 // Human resources
-	Assets     float64 // Assets
+	Human      float64 // Human resources (and human pool assignments)
+	Assets     float64 // Assets (and asset pool assignments)
 }
 
-// Total returns the sum of all categories.
+// Total returns the sum of all categories, added exactly at the Money scale.
 func (c *CostBreakdown) Total() float64 {
-	return c.Activities + c.Materials + c.Human + c.Assets
+	total := unit.NewMoney(c.Activities, "").Add(unit.NewMoney(c.Materials, "")).
+		Add(unit.NewMoney(c.Human, "")).Add(unit.NewMoney(c.Assets, ""))
+	return total.Float64()
+}
+
+// MoneyBreakdown is CostBreakdown with exact amounts.
+type MoneyBreakdown struct {
+	Activities unit.Money
+	Materials  unit.Money
+	Human      unit.Money
+	Assets     unit.Money
+}
+
+// Total returns the exact sum of all categories.
+func (m *MoneyBreakdown) Total() unit.Money {
+	return m.Activities.Add(m.Materials).Add(m.Human).Add(m.Assets)
+}
+
+// Float returns the breakdown with float amounts.
+func (m *MoneyBreakdown) Float() CostBreakdown {
+	return CostBreakdown{
+		Activities: m.Activities.Float64(),
+		Materials:  m.Materials.Float64(),
+		Human:      m.Human.Float64(),
+		Assets:     m.Assets.Float64(),
+	}
 }
 
 // CostBreakdown returns the price breakdown by category for this activity and all descendants.
+// Amounts are summed exactly (see MoneyBreakdown).
 func (a *Activity) CostBreakdown() CostBreakdown {
-	var cb CostBreakdown
-	cb.Activities = a.Price.Value
-	for _, m := range a.ComplexMaterials {
-		cb.Materials += m.CalculatePrice()
-	}
-	for _, m := range a.CountableMaterials {
-		cb.Materials += m.CalculatePrice()
-	}
-	for _, m := range a.MeasurableMaterials {
-		cb.Materials += m.CalculatePrice()
-	}
-	for _, h := range a.HumanResources {
-		cb.Human += h.CalculatePrice()
-	}
-	for _, as := range a.Assets {
-		cb.Assets += as.CalculatePrice()
-	}
+	mb := a.MoneyBreakdown()
+	return mb.Float()
+}
+
+// MoneyBreakdown returns the exact price breakdown by category for this activity and all descendants.
+func (a *Activity) MoneyBreakdown() MoneyBreakdown {
+	mb := a.ownMoneyBreakdown()
 	for _, child := range a.Activities {
-		childCB := child.CostBreakdown()
-		cb.Activities += childCB.Activities
-		cb.Materials += childCB.Materials
-		cb.Human += childCB.Human
-		cb.Assets += childCB.Assets
+		childMB := child.MoneyBreakdown()
+		mb.Activities = mb.Activities.Add(childMB.Activities)
+		mb.Materials = mb.Materials.Add(childMB.Materials)
+		mb.Human = mb.Human.Add(childMB.Human)
+		mb.Assets = mb.Assets.Add(childMB.Assets)
 	}
-	return cb
+	return mb
+}
+
+// ownCostBreakdown returns the price breakdown of the activity's own scope, without sub-activities.
+func (a *Activity) ownCostBreakdown() CostBreakdown {
+	mb := a.ownMoneyBreakdown()
+	return mb.Float()
 }
 
-// SlackInfo holds ES, EF, LS, LF and Slack for an activity (in hours).
+// ownMoneyBreakdown returns the exact price breakdown of the activity's own scope, without sub-activities.
+// Prices are added as they are, whatever their currency (see CostBreakdownIn).
+func (a *Activity) ownMoneyBreakdown() MoneyBreakdown {
+	var mb MoneyBreakdown
+	for _, item := range a.ownPricedItems() {
+		mb.add(item.category, item.value)
+	}
+	return mb
+}
+
+// SlackInfo holds ES, EF, LS, LF and the floats for an activity (in hours).
+// Floats are not clamped: a negative total float means a constraint or the deadline cannot be met.
 type SlackInfo struct {
-	ES    float64 // Early Start
-	EF    float64 // Early Finish
-	LS    float64 // Late Start
-	LF    float64 // Late Finish
-	Slack float64 // LS - ES (or LF - EF)
+	ES         float64 // Early Start
+	EF         float64 // Early Finish
+	LS         float64 // Late Start
+	LF         float64 // Late Finish
+	TotalFloat float64 // LS - ES: delay allowed without delaying the project end (or the deadline)
+	FreeFloat  float64 // Delay allowed without delaying the early start of any successor
+	Slack      float64 // Same as TotalFloat (kept for compatibility)
+
+	IndependentFloat float64 // Delay allowed when predecessors finish late and successors start early (never negative)
+	InterferingFloat float64 // TotalFloat - FreeFloat: delay that consumes float of the successors
 }
 
 // CalculateSlack returns a map of activity -> SlackInfo for all activities in the tree.
-// Activities on the critical path have Slack = 0.
+// Activities on the critical path have TotalFloat = 0; with constraints or a deadline, it can be negative (the activity is late).
+// Durations are converted with calendar time (1 day = 24h);
+// see Project.CalculateSlack for the project policy.
 func (a *Activity) CalculateSlack() map[*Activity]SlackInfo {
-	if a.hasExplicitDependencies() {
-		m, _ := a.cpmForwardBackward()
-		return m
+	m, _ := a.calculateSlack(defaultScheduleOptions())
+	return m
+}
+
+// calculateSlack returns the slack map and the project end (hours).
+func (a *Activity) calculateSlack(opts scheduleOptions) (map[*Activity]SlackInfo, float64) {
+	if a.usesFullCPM(opts) {
+		return a.cpmForwardBackward(opts)
 	}
-	_, projectEnd := a.criticalPathAndDuration()
+	_, projectEnd := a.criticalPathAndDuration(opts)
 	m := make(map[*Activity]SlackInfo)
-	a.forwardPass(0, m)
-	a.backwardPass(projectEnd, m)
-	return m
+	a.forwardPass(0, m, opts)
+	a.backwardPass(projectEnd, m, opts)
+	a.independentFloat(0, projectEnd, m)
+	return m, projectEnd
 }
 
-func (a *Activity) forwardPass(parentEF float64, m map[*Activity]SlackInfo) {
-	myHours := a.Duration.ToHours()
+func (a *Activity) forwardPass(parentEF float64, m map[*Activity]SlackInfo, opts scheduleOptions) {
+	myHours := opts.activityHours(a)
 	es := parentEF
 	ef := es + myHours
 
@@ -137,7 +218,7 @@ func (a *Activity) forwardPass(parentEF float64, m map[*Activity]SlackInfo) {
 	childStart := ef
 	if len(a.Activities) > 0 {
 		for _, child := range a.Activities {
-			child.forwardPass(childStart, m)
+			child.forwardPass(childStart, m, opts)
 			childInfo := m[child]
 			if childInfo.EF > ef {
 				ef = childInfo.EF
@@ -148,7 +229,7 @@ func (a *Activity) forwardPass(parentEF float64, m map[*Activity]SlackInfo) {
 	m[a] = SlackInfo{ES: es, EF: ef}
 }
 
-func (a *Activity) backwardPass(projectEnd float64, m map[*Activity]SlackInfo) {
+func (a *Activity) backwardPass(projectEnd float64, m map[*Activity]SlackInfo, opts scheduleOptions) {
 	info, ok := m[a]
 	if !ok {
 		return
@@ -160,7 +241,7 @@ func (a *Activity) backwardPass(projectEnd float64, m map[*Activity]SlackInfo) {
 	} else {
 		lf = projectEnd
 		for _, child := range a.Activities {
-			child.backwardPass(projectEnd, m)
+			child.backwardPass(projectEnd, m, opts)
 			childInfo := m[child]
 			if childInfo.LS < lf {
 				lf = childInfo.LS
@@ -168,18 +249,41 @@ func (a *Activity) backwardPass(projectEnd float64, m map[*Activity]SlackInfo) {
 		}
 	}
 
-	myHours := a.Duration.ToHours()
+	myHours := opts.activityHours(a)
 	ls := lf - myHours
-	slack := ls - info.ES
-	if slack < 0 {
-		slack = 0
+	total := ls - info.ES
+	// Children start when this activity's own work finishes: a parent has no free float.
+	free := 0.0
+	if len(a.Activities) == 0 {
+		free = projectEnd - info.EF
 	}
 	m[a] = SlackInfo{
-		ES:    info.ES,
-		EF:    info.EF,
-		LS:    ls,
-		LF:    lf,
-		Slack: slack,
+		ES:         info.ES,
+		EF:         info.EF,
+		LS:         ls,
+		LF:         lf,
+		TotalFloat: total,
+		FreeFloat:  free,
+		Slack:      total,
+
+		InterferingFloat: total - free,
+	}
+}
+
+// independentFloat sets the independent float in tree mode: a leaf starts at the latest own-work finish of
+// its parent (parentLF) and must finish by the project end; a parent has no free or independent float.
+func (a *Activity) independentFloat(parentLF, projectEnd float64, m map[*Activity]SlackInfo) {
+	info := m[a]
+	if len(a.Activities) == 0 {
+		info.IndependentFloat = projectEnd - (parentLF + (info.EF - info.ES))
+		if info.IndependentFloat < 0 {
+			info.IndependentFloat = 0
+		}
+		m[a] = info
+		return
+	}
+	for _, child := range a.Activities {
+		child.independentFloat(info.LF, projectEnd, m)
 	}
 }
 
@@ -187,15 +291,15 @@ func (a *Activity) backwardPass(projectEnd float64, m map[*Activity]SlackInfo) {
 // For a leaf (no children), the path is just this activity.
 // For a node with children, it picks the child whose subtree has the longest
 // total duration and appends that child's critical path to this activity.
-func (a *Activity) criticalPathAndDuration() ([]*Activity, float64) {
-	myHours := a.Duration.ToHours()
+func (a *Activity) criticalPathAndDuration(opts scheduleOptions) ([]*Activity, float64) {
+	myHours := opts.activityHours(a)
 	if len(a.Activities) == 0 {
 		return []*Activity{a}, myHours
 	}
 	var bestPath []*Activity
 	bestHours := -1.0
 	for _, child := range a.Activities {
-		childPath, childHours := child.criticalPathAndDuration()
+		childPath, childHours := child.criticalPathAndDuration(opts)
 		total := myHours + childHours
 		if total > bestHours {
 			bestHours = total
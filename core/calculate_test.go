@@ -227,3 +227,55 @@ func TestActivity_CalculateSlack(t *testing.T) {
 		t.Errorf("Non-critical Short has slack %.2f, want positive", info.Slack)
 	}
 }
+
+func TestActivity_CalculateMoneyIsExact(t *testing.T) {
+	root := activityWithDefaults("Root", "")
+	for i := 0; i < 1000; i++ {
+		root.AddCountableMaterial(material.NewCountableMaterial("Washer", "", *unit.NewPrice(0.1, "EUR"), 1))
+	}
+	if got := root.CalculatePrice(); got != 100 {
+		t.Errorf("CalculatePrice() = %v, want exactly 100", got)
+	}
+	if got := root.CalculateMoney().String(); got != "100.00 EUR" {
+		t.Errorf("CalculateMoney() = %q, want \"100.00 EUR\"", got)
+	}
+	mb := root.MoneyBreakdown()
+	if total := mb.Total(); total.Amount != 100*unit.MoneyScale {
+		t.Errorf("MoneyBreakdown().Total() = %v, want 100", total)
+	}
+}
+
+func TestActivity_BuilderRatesUseProjectConversion(t *testing.T) {
+	// A 1-day activity with a rate-priced worker and asset: both bill 8 hours / 1 day with the project policy.
+	act := NewActivity("Wiring", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	proj := NewProject(act)
+	working := unit.WorkingTimeConversion(8, 5)
+	proj.Conversion = &working
+
+	electrician, err := human.NewHumanResourceBuilder().
+		WithName("Electrician").
+		WithDuration(act.Duration).
+		WithHourlyRate(*unit.NewPrice(10, "EUR")).
+		WithConversion(proj.DurationConversion()).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	drill, err := asset.NewAssetBuilder().
+		WithName("Drill").
+		WithDuration(act.Duration).
+		WithDailyRate(*unit.NewPrice(80, "EUR")).
+		WithConversion(proj.DurationConversion()).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	act.AddHumanResource(electrician)
+	act.AddAsset(drill)
+	if electrician.Price.Value != 80 || drill.Price.Value != 80 {
+		t.Errorf("electrician = %v, drill = %v, want 80 each (8h at 10/hour, 1 day at 80/day)", electrician.Price.Value, drill.Price.Value)
+	}
+	if got := act.CalculatePrice(); got != 160 {
+		t.Errorf("CalculatePrice() = %v, want 160", got)
+	}
+}
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"explosio/core/unit"
+)
+
+// AccrualMethod tells when the cost of an item is incurred over the activity's work window.
+type AccrualMethod string
+
+const (
+	AccrueLinear AccrualMethod = "linear" // Spread over the working days of the activity
+	AccrueStart  AccrualMethod = "start"  // On the start day (e.g. materials paid on delivery)
+	AccrueEnd    AccrualMethod = "end"    // On the finish day (e.g. materials paid on completion)
+)
+
+// IsValidAccrualMethod returns true if m is a known accrual method (or empty, meaning the default).
+func IsValidAccrualMethod(m AccrualMethod) bool {
+	switch m {
+	case "", AccrueLinear, AccrueStart, AccrueEnd:
+		return true
+	}
+	return false
+}
+
+// CashFlowConfig holds options for the cash-flow projection.
+type CashFlowConfig struct {
+	Period          LoadPeriod    // Bucket length (default: week)
+	MaterialAccrual AccrualMethod // When materials are paid (default: start); labor, assets and activity prices accrue linearly
+}
+
+// CashFlowPeriod is the cost incurred in a period, by category, and the cumulative cost up to its end.
+type CashFlowPeriod struct {
+	Start unit.Date // First day of the period
+	CostBreakdown
+	Cumulative float64
+}
+
+// CashFlow is the time-phased cost of a project.
+type CashFlow struct {
+	Period   LoadPeriod
+	Currency string
+	Periods  []CashFlowPeriod // Consecutive, from the period of the project start to the last cost
+}
+
+// CashFlow spreads the costs of the tree over the early schedule starting at projectStart (calendar time),
+// adding prices without currency conversion as CostBreakdown does; see Project.CashFlow for the project settings.
+func (a *Activity) CashFlow(projectStart unit.Date, cfg CashFlowConfig) *CashFlow {
+	cf, _ := a.cashFlow(projectStart, cfg, defaultScheduleOptions(), nil)
+	return cf
+}
+
+// cashFlow spreads the own costs of each activity over its own work window: linearly in proportion to the
+// working hours of each day (as the resource workload), or on the start or finish day for materials.
+// The totals per category match CostBreakdown, or CostBreakdownIn with a currency conversion; it fails if a
+// currency has no exchange rate on the conversion date.
+func (a *Activity) cashFlow(projectStart unit.Date, cfg CashFlowConfig, opts scheduleOptions, c *CurrencyConversion) (*CashFlow, error) {
+	if cfg.Period == "" {
+		cfg.Period = PeriodWeek
+	}
+	if cfg.MaterialAccrual == "" {
+		cfg.MaterialAccrual = AccrueStart
+	}
+	opts.start = &projectStart
+	m, _ := a.calculateSlack(opts)
+
+	days := make(map[string]*CostBreakdown)
+	at := func(day string) *CostBreakdown {
+		cb, ok := days[day]
+		if !ok {
+			cb = &CostBreakdown{}
+			days[day] = cb
+		}
+		return cb
+	}
+	first, last := projectStart.StartOfDay(), projectStart.StartOfDay()
+	for _, act := range a.GetActivities() {
+		info, ok := m[act]
+		if !ok {
+			continue
+		}
+		mb, err := act.ownMoneyBreakdownIn(c)
+		if err != nil {
+			return nil, err
+		}
+		own := mb.Float()
+		if own.Total() == 0 {
+			continue
+		}
+		linear, from, to := dayShares(projectStart, opts, info.ES, info.ES+opts.activityHours(act))
+		if from.Time.Before(first.Time) {
+			first = from
+		}
+		if to.Time.After(last.Time) {
+			last = to
+		}
+		for day, s := range linear {
+			cb := at(day)
+			cb.Activities += own.Activities * s
+			cb.Human += own.Human * s
+			cb.Assets += own.Assets * s
+		}
+		switch cfg.MaterialAccrual {
+		case AccrueEnd:
+			at(to.String()).Materials += own.Materials
+		case AccrueLinear:
+			for day, s := range linear {
+				at(day).Materials += own.Materials * s
+			}
+		default:
+			at(from.String()).Materials += own.Materials
+		}
+	}
+
+	cf := &CashFlow{Period: cfg.Period, Currency: c.reportCurrency(a.Price.Currency)}
+	index := make(map[string]int)
+	for d := periodStart(first, cfg.Period); !d.Time.After(last.Time); d = nextPeriod(d, cfg.Period) {
+		index[d.String()] = len(cf.Periods)
+		cf.Periods = append(cf.Periods, CashFlowPeriod{Start: d})
+	}
+	for day, cb := range days {
+		d, _ := unit.ParseDate(day)
+		p := &cf.Periods[index[periodStart(d, cfg.Period).String()]]
+		p.Activities += cb.Activities
+		p.Materials += cb.Materials
+		p.Human += cb.Human
+		p.Assets += cb.Assets
+	}
+	total := 0.0
+	for i := range cf.Periods {
+		total += cf.Periods[i].Total()
+		cf.Periods[i].Cumulative = total
+	}
+	return cf, nil
+}
+
+// CashFlow spreads the costs of the project over its schedule using the project settings. Costs are in the
+// reporting currency, converted with the exchange rates effective on the conversion date; it fails if a currency
+// has no exchange rate on that date.
+func (p *Project) CashFlow(projectStart unit.Date, cfg CashFlowConfig) (*CashFlow, error) {
+	c := p.CurrencyConversion("", p.ConversionDate())
+	return p.Root.cashFlow(projectStart, cfg, p.scheduleOptions(), &c)
+}
+
+// PrintCashFlow prints the cost per period by category and the cumulative cost.
+func PrintCashFlow(cf *CashFlow) {
+	fmt.Println("--------------------------------")
+	fmt.Printf("   Cash Flow per %s (%s)\n", cf.Period, cf.Currency)
+	fmt.Println("--------------------------------")
+	fmt.Printf("%-10s %11s %11s %11s %11s %11s %12s\n", "Period", "Activities", "Materials", "Labor", "Assets", "Total", "Cumulative")
+	fmt.Println(strings.Repeat("-", 10+12*5+13))
+	for _, p := range cf.Periods {
+		fmt.Printf("%-10s %11.2f %11.2f %11.2f %11.2f %11.2f %12.2f\n", p.Start.String(),
+			p.Activities, p.Materials, p.Human, p.Assets, p.Total(), p.Cumulative)
+	}
+}
+
+// WriteCashFlowCSV writes the cash flow as CSV, one row per period:
+// period_start, activities, materials, labor, assets, total, cumulative, currency.
+func WriteCashFlowCSV(out io.Writer, cf *CashFlow) error {
+	cw := csv.NewWriter(out)
+	if err := cw.Write([]string{"period_start", "activities", "materials", "labor", "assets", "total", "cumulative", "currency"}); err != nil {
+		return err
+	}
+	format := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	for _, p := range cf.Periods {
+		row := []string{p.Start.String(), format(p.Activities), format(p.Materials), format(p.Human), format(p.Assets),
+			format(p.Total()), format(p.Cumulative), cf.Currency}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
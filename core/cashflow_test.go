@@ -0,0 +1,86 @@
+package core
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"explosio/core/material"
+	"explosio/core/unit"
+)
+
+// buildCashFlowTree returns Plaster (4d, 400 EUR, 200 EUR of tiles, electrician 200 EUR) and Tiling (3d, 300 EUR) in parallel.
+func buildCashFlowTree() *Activity {
+	root, plaster, tiling := buildLinkTestTree()
+	plaster.Price.Value = 400
+	plaster.AddCountableMaterial(material.NewCountableMaterial("Tiles", "", *unit.NewPrice(20, "EUR"), 10))
+	plaster.AddHumanResource(newElectrician())
+	tiling.Price.Value = 300
+	return root
+}
+
+func TestCashFlow_MaterialAccrual(t *testing.T) {
+	root := buildCashFlowTree()
+	start := unit.NewDate(2026, time.March, 6) // Friday: Plaster runs Fri-Mon, Tiling Fri-Sun
+
+	tests := []struct {
+		accrual AccrualMethod
+		want    []float64 // Total per week
+	}{
+		{AccrueStart, []float64{950, 150}},
+		{AccrueEnd, []float64{750, 350}},
+		{AccrueLinear, []float64{900, 200}},
+	}
+	for _, tt := range tests {
+		cf := root.CashFlow(start, CashFlowConfig{Period: PeriodWeek, MaterialAccrual: tt.accrual})
+		if len(cf.Periods) != 2 || cf.Periods[0].Start.String() != "2026-03-02" {
+			t.Fatalf("%s: periods = %+v, want weeks of 2026-03-02 and 2026-03-09", tt.accrual, cf.Periods)
+		}
+		for i, want := range tt.want {
+			if got := cf.Periods[i].Total(); got != want {
+				t.Errorf("%s: week %d total = %v, want %v", tt.accrual, i, got, want)
+			}
+		}
+		if last := cf.Periods[1].Cumulative; last != root.CalculatePrice() {
+			t.Errorf("%s: cumulative = %v, want the total price %v", tt.accrual, last, root.CalculatePrice())
+		}
+	}
+}
+
+func TestCashFlow_CategoriesAndCSV(t *testing.T) {
+	root := buildCashFlowTree()
+	cf := root.CashFlow(unit.NewDate(2026, time.March, 2), CashFlowConfig{Period: PeriodMonth})
+	if len(cf.Periods) != 1 {
+		t.Fatalf("periods = %d, want 1 month", len(cf.Periods))
+	}
+	if got, want := cf.Periods[0].CostBreakdown, root.CostBreakdown(); got != want {
+		t.Errorf("breakdown = %+v, want %+v", got, want)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCashFlowCSV(&buf, cf); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || lines[1] != "2026-03-01,700.00,200.00,200.00,0.00,1100.00,1100.00,EUR" {
+		t.Errorf("CSV = %q", lines)
+	}
+}
+
+func TestProject_CashFlowConvertsCosts(t *testing.T) {
+	proj, tiling := buildCurrencyProject()
+	cf, err := proj.CashFlow(unit.NewDate(2026, time.March, 2), CashFlowConfig{Period: PeriodMonth})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// June rate 1.10: tiles 200 CHF = 220 EUR, labor 200, Tiling 100 EUR.
+	if got := cf.Periods[len(cf.Periods)-1].Cumulative; got != 520 || cf.Currency != "EUR" {
+		t.Errorf("cumulative = %v %s, want 520 EUR", got, cf.Currency)
+	}
+
+	tiling.Price.Currency = "USD"
+	if _, err := proj.CashFlow(unit.NewDate(2026, time.March, 2), CashFlowConfig{Period: PeriodMonth}); err == nil {
+		t.Error("expected an error for a price without exchange rate")
+	}
+}
@@ -0,0 +1,159 @@
+package core
+
+import (
+	"fmt"
+
+	"explosio/core/catalog"
+	"explosio/core/material"
+	"explosio/core/resource"
+	"explosio/core/unit"
+)
+
+// ApplyCatalog resolves from the catalog the prices of materials, human resources, assets and pool resources that
+// reference an entry by code. The entry price, supplier and rate unit always apply; name, description, waste and
+// pack size only fill fields left empty in the project. Rates of human resources and assets are turned into a total
+// with the project duration conversion, pool rates into a rate per hour. Codes missing from the catalog or of the
+// wrong kind are returned as problems and leave the item unchanged.
+func (p *Project) ApplyCatalog(c *catalog.Catalog) []ValidationError {
+	var problems []ValidationError
+	conv := p.DurationConversion()
+	lookup := func(activity, item, code string, kind catalog.Kind) *catalog.Entry {
+		e := c.Find(code)
+		switch {
+		case e == nil:
+			problems = append(problems, ValidationError{Activity: activity, Message: fmt.Sprintf("%s: catalog code %q not found", item, code)})
+		case e.Kind != kind:
+			problems = append(problems, ValidationError{Activity: activity, Message: fmt.Sprintf("%s: catalog entry %q is %s, not %s", item, code, e.Kind, kind)})
+		default:
+			return e
+		}
+		return nil
+	}
+	measurable := func(activity string, m *material.MeasurableMaterial) {
+		if m.Code == "" {
+			return
+		}
+		item := fmt.Sprintf("material %q", m.Name)
+		if e := lookup(activity, item, m.Code, catalog.KindMeasurable); e != nil {
+			if err := applyMeasurable(e, m); err != nil {
+				problems = append(problems, ValidationError{Activity: activity, Message: fmt.Sprintf("%s: %v", item, err)})
+			}
+		}
+	}
+	for _, act := range p.Root.GetActivities() {
+		for _, m := range act.CountableMaterials {
+			if m.Code == "" {
+				continue
+			}
+			if e := lookup(act.Name, fmt.Sprintf("material %q", m.Name), m.Code, catalog.KindCountable); e != nil {
+				applyEntry(e, &m.Name, &m.Description, &m.Supplier, &m.Price, &m.WastePercent)
+				if m.PackSize == 0 {
+					m.PackSize = int(e.PackSize)
+				}
+			}
+		}
+		for _, m := range act.MeasurableMaterials {
+			measurable(act.Name, m)
+		}
+		for _, m := range act.ComplexMaterials {
+			if m.Code != "" {
+				if e := lookup(act.Name, fmt.Sprintf("material %q", m.Name), m.Code, catalog.KindCountable); e != nil {
+					applyEntry(e, &m.Name, &m.Description, &m.Supplier, &m.Price, &m.WastePercent)
+					if m.PackSize == 0 {
+						m.PackSize = int(e.PackSize)
+					}
+				}
+			}
+			if m.MeasurableMaterial != nil {
+				measurable(act.Name, m.MeasurableMaterial)
+			}
+		}
+		for _, h := range act.HumanResources {
+			if h.Code == "" {
+				continue
+			}
+			if e := lookup(act.Name, fmt.Sprintf("human resource %q", h.Name), h.Code, catalog.KindHuman); e != nil {
+				applyRate(e, &h.PricedResource, conv)
+			}
+		}
+		for _, a := range act.Assets {
+			if a.Code == "" {
+				continue
+			}
+			if e := lookup(act.Name, fmt.Sprintf("asset %q", a.Name), a.Code, catalog.KindAsset); e != nil {
+				applyRate(e, &a.PricedResource, conv)
+			}
+		}
+	}
+	for _, res := range p.Resources {
+		if res.Code == "" {
+			continue
+		}
+		kind := catalog.KindHuman
+		if res.IsAsset() {
+			kind = catalog.KindAsset
+		}
+		e := lookup(p.Root.Name, fmt.Sprintf("resource %q", res.Name), res.Code, kind)
+		if e == nil {
+			continue
+		}
+		if e.Per == "" {
+			problems = append(problems, ValidationError{Activity: p.Root.Name, Message: fmt.Sprintf("resource %q: catalog entry %q has no rate unit", res.Name, e.Code)})
+			continue
+		}
+		if res.Name == "" {
+			res.Name = e.Name
+		}
+		res.Rate = e.Price.Money().Div(conv.Hours(1, e.Per), unit.RoundHalfEven).Price()
+	}
+	return problems
+}
+
+// applyMeasurable resolves a measurable material with a code. The catalog unit becomes the price unit, and the
+// catalog pack size is converted to the unit of the quantity.
+func applyMeasurable(e *catalog.Entry, m *material.MeasurableMaterial) error {
+	if !e.Unit.Compatible(m.Quantity.Unit) {
+		return fmt.Errorf("catalog unit %s is not compatible with %s", e.Unit, m.Quantity.Unit)
+	}
+	applyEntry(e, &m.Name, &m.Description, &m.Supplier, &m.Price, &m.WastePercent)
+	m.PriceUnit = ""
+	if e.Unit != m.Quantity.Unit {
+		m.PriceUnit = e.Unit
+	}
+	if m.PackSize == 0 && e.PackSize > 0 {
+		m.PackSize, _ = unit.ConvertUnit(e.PackSize, e.Unit, m.Quantity.Unit)
+	}
+	return nil
+}
+
+// applyEntry copies the price and supplier of the entry and fills the empty descriptive fields.
+func applyEntry(e *catalog.Entry, name, description, supplier *string, price *unit.Price, waste *float64) {
+	*price = e.Price
+	if e.Supplier != "" {
+		*supplier = e.Supplier
+	}
+	if *name == "" {
+		*name = e.Name
+	}
+	if *description == "" {
+		*description = e.Description
+	}
+	if *waste == 0 {
+		*waste = e.WastePercent
+	}
+}
+
+// applyRate prices a human resource or asset: the entry price is a rate per e.Per, or a fixed price if Per is empty.
+func applyRate(e *catalog.Entry, r *resource.PricedResource, conv unit.DurationConversion) {
+	if r.Name == "" {
+		r.Name = e.Name
+	}
+	if r.Description == "" {
+		r.Description = e.Description
+	}
+	if e.Per == "" {
+		r.SetTotalPrice(e.Price)
+		return
+	}
+	r.SetRateWith(e.Price, e.Per, conv)
+}
@@ -0,0 +1,268 @@
+// Package catalog defines a reusable price list of materials, human resource roles and assets.
+// Project files reference entries by code, and prices are resolved from the catalog when the project is loaded.
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"explosio/core/unit"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CatalogVersion is the current catalog file format version.
+const CatalogVersion = "1.0"
+
+// Kind tells which project items an entry prices.
+type Kind string
+
+const (
+	KindCountable  Kind = "countable"  // Countable and complex materials (price per piece)
+	KindMeasurable Kind = "measurable" // Measurable materials (price per Unit)
+	KindHuman      Kind = "human"      // Human resources and people of the pool (price per Per)
+	KindAsset      Kind = "asset"      // Assets and equipment of the pool (price per Per)
+)
+
+// Kinds lists the entry kinds in display order.
+var Kinds = []Kind{KindCountable, KindMeasurable, KindHuman, KindAsset}
+
+// IsValid returns true if k is a known kind.
+func (k Kind) IsValid() bool {
+	for _, v := range Kinds {
+		if k == v {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMaterial returns true for the material kinds.
+func (k Kind) IsMaterial() bool {
+	return k == KindCountable || k == KindMeasurable
+}
+
+// Entry is a catalog item with its unit price.
+type Entry struct {
+	Code         string              `json:"code" yaml:"code"`
+	Kind         Kind                `json:"kind" yaml:"kind"`
+	Name         string              `json:"name" yaml:"name"`
+	Description  string              `json:"description,omitempty" yaml:"description,omitempty"`
+	Supplier     string              `json:"supplier,omitempty" yaml:"supplier,omitempty"`
+	Price        unit.Price          `json:"price" yaml:"price"`                   // Per piece, per Unit or per Per
+	Unit         unit.MeasurableUnit `json:"unit,omitempty" yaml:"unit,omitempty"` // Measurable: unit of the price and pack size
+	Per          unit.DurationUnit   `json:"per,omitempty" yaml:"per,omitempty"`   // Human and asset: time unit of the rate (empty = fixed price)
+	PackSize     float64             `json:"packSize,omitempty" yaml:"packsize,omitempty"`
+	WastePercent float64             `json:"wastePercent,omitempty" yaml:"wastepercent,omitempty"`
+}
+
+// Validate returns the problems of the entry, or nil if it is usable.
+func (e *Entry) Validate() []error {
+	var errs []error
+	if strings.TrimSpace(e.Code) == "" {
+		errs = append(errs, fmt.Errorf("entry %q: empty code", e.Name))
+	}
+	if !e.Kind.IsValid() {
+		errs = append(errs, fmt.Errorf("%s: unknown kind %q", e.Code, e.Kind))
+	}
+	if e.Price.Value < 0 {
+		errs = append(errs, fmt.Errorf("%s: negative price", e.Code))
+	}
+	if e.Kind == KindMeasurable && !e.Unit.IsValid() {
+		errs = append(errs, fmt.Errorf("%s: unknown unit %q", e.Code, e.Unit))
+	}
+	if e.Kind != KindMeasurable && e.Unit != "" {
+		errs = append(errs, fmt.Errorf("%s: unit is only used by measurable entries", e.Code))
+	}
+	if e.Per != "" && (e.Kind.IsMaterial() || !validRateUnit(e.Per)) {
+		errs = append(errs, fmt.Errorf("%s: invalid rate unit %q", e.Code, e.Per))
+	}
+	if e.PackSize < 0 {
+		errs = append(errs, fmt.Errorf("%s: negative pack size", e.Code))
+	}
+	if e.Kind == KindCountable && e.PackSize != float64(int(e.PackSize)) {
+		errs = append(errs, fmt.Errorf("%s: pack size must be a whole number of pieces", e.Code))
+	}
+	if e.WastePercent < 0 {
+		errs = append(errs, fmt.Errorf("%s: negative waste percent", e.Code))
+	}
+	return errs
+}
+
+// validRateUnit returns true for the duration units a rate can refer to.
+func validRateUnit(u unit.DurationUnit) bool {
+	switch u {
+	case unit.DurationUnitMinute, unit.DurationUnitHour, unit.DurationUnitDay,
+		unit.DurationUnitWeek, unit.DurationUnitMonth, unit.DurationUnitYear:
+		return true
+	}
+	return false
+}
+
+// PriceLabel returns the unit price with its basis (e.g. "12.50 EUR/kg", "40.00 EUR/hour").
+func (e *Entry) PriceLabel() string {
+	s := fmt.Sprintf("%.2f %s", e.Price.Value, e.Price.Currency)
+	switch {
+	case e.Kind == KindMeasurable:
+		return s + "/" + string(e.Unit)
+	case e.Kind == KindCountable:
+		return s + "/pc"
+	case e.Per != "":
+		return s + "/" + string(e.Per)
+	}
+	return s
+}
+
+// Catalog is a list of entries with unique codes.
+type Catalog struct {
+	Version string   `json:"version" yaml:"version"`
+	Entries []*Entry `json:"entries" yaml:"entries"`
+}
+
+// New returns an empty catalog.
+func New() *Catalog {
+	return &Catalog{Version: CatalogVersion}
+}
+
+// Find returns the entry with the given code (case-insensitive), or nil.
+func (c *Catalog) Find(code string) *Entry {
+	for _, e := range c.Entries {
+		if strings.EqualFold(e.Code, code) {
+			return e
+		}
+	}
+	return nil
+}
+
+// Search returns the entries whose code, name, description or supplier contains query (case-insensitive),
+// sorted by code. An empty query returns every entry.
+func (c *Catalog) Search(query string) []*Entry {
+	q := strings.ToLower(strings.TrimSpace(query))
+	var out []*Entry
+	for _, e := range c.Entries {
+		text := strings.ToLower(e.Code + "\x00" + e.Name + "\x00" + e.Description + "\x00" + e.Supplier)
+		if strings.Contains(text, q) {
+			out = append(out, e)
+		}
+	}
+	sortEntries(out)
+	return out
+}
+
+// OfKind returns the entries of the given kinds sorted by code.
+func (c *Catalog) OfKind(kinds ...Kind) []*Entry {
+	var out []*Entry
+	for _, e := range c.Entries {
+		for _, k := range kinds {
+			if e.Kind == k {
+				out = append(out, e)
+				break
+			}
+		}
+	}
+	sortEntries(out)
+	return out
+}
+
+// Set adds the entry, or replaces the entry with the same code. Entries are kept sorted by code.
+func (c *Catalog) Set(e *Entry) {
+	for i, old := range c.Entries {
+		if strings.EqualFold(old.Code, e.Code) {
+			c.Entries[i] = e
+			return
+		}
+	}
+	c.Entries = append(c.Entries, e)
+	sortEntries(c.Entries)
+}
+
+// Remove deletes the entry with the given code and reports whether it existed.
+func (c *Catalog) Remove(code string) bool {
+	for i, e := range c.Entries {
+		if strings.EqualFold(e.Code, code) {
+			c.Entries = append(c.Entries[:i], c.Entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Validate returns the problems of all entries, including duplicate codes.
+func (c *Catalog) Validate() []error {
+	var errs []error
+	seen := make(map[string]bool)
+	for _, e := range c.Entries {
+		errs = append(errs, e.Validate()...)
+		key := strings.ToLower(e.Code)
+		if key != "" && seen[key] {
+			errs = append(errs, fmt.Errorf("%s: duplicate code", e.Code))
+		}
+		seen[key] = true
+	}
+	return errs
+}
+
+func sortEntries(entries []*Entry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return strings.ToLower(entries[i].Code) < strings.ToLower(entries[j].Code)
+	})
+}
+
+// WriteJSON writes the catalog to w (JSON format).
+func (c *Catalog) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c)
+}
+
+// WriteYAML writes the catalog to w (YAML format).
+func (c *Catalog) WriteYAML(w io.Writer) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// ReadJSON reads a catalog from r (JSON format).
+func ReadJSON(r io.Reader) (*Catalog, error) {
+	var c Catalog
+	if err := json.NewDecoder(r).Decode(&c); err != nil {
+		return nil, fmt.Errorf("decode JSON: %w", err)
+	}
+	if c.Version == "" {
+		c.Version = CatalogVersion
+	}
+	return &c, nil
+}
+
+// ReadYAML reads a catalog from r (YAML format).
+func ReadYAML(r io.Reader) (*Catalog, error) {
+	var c Catalog
+	if err := yaml.NewDecoder(r).Decode(&c); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("decode YAML: %w", err)
+	}
+	if c.Version == "" {
+		c.Version = CatalogVersion
+	}
+	return &c, nil
+}
+
+// PrintEntries prints the entries as a table: code, kind, name, unit price, pack size and supplier.
+func PrintEntries(entries []*Entry) {
+	fmt.Printf("%-12s %-10s %-28s %20s %8s  %s\n", "Code", "Kind", "Name", "Price", "Pack", "Supplier")
+	fmt.Println(strings.Repeat("-", 96))
+	for _, e := range entries {
+		pack := ""
+		if e.PackSize > 0 {
+			pack = strconv.FormatFloat(e.PackSize, 'f', -1, 64)
+		}
+		row := fmt.Sprintf("%-12s %-10s %-28s %20s %8s  %s", e.Code, e.Kind, e.Name, e.PriceLabel(), pack, e.Supplier)
+		fmt.Println(strings.TrimRight(row, " "))
+	}
+}
@@ -0,0 +1,105 @@
+package catalog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"explosio/core/unit"
+)
+
+func buildTestCatalog() *Catalog {
+	c := New()
+	c.Set(&Entry{Code: "SCR-440", Kind: KindCountable, Name: "Screws 4x40", Supplier: "Acme", Price: *unit.NewPrice(0.1, "EUR"), PackSize: 100})
+	c.Set(&Entry{Code: "CEM-25", Kind: KindMeasurable, Name: "Cement", Price: *unit.NewPrice(0.3, "EUR"), Unit: unit.UnitKilogram, PackSize: 25})
+	c.Set(&Entry{Code: "ELEC", Kind: KindHuman, Name: "Electrician", Price: *unit.NewPrice(40, "EUR"), Per: unit.DurationUnitHour})
+	return c
+}
+
+func TestCatalog_SetFindRemove(t *testing.T) {
+	c := buildTestCatalog()
+	if got := []string{c.Entries[0].Code, c.Entries[1].Code, c.Entries[2].Code}; strings.Join(got, ",") != "CEM-25,ELEC,SCR-440" {
+		t.Errorf("entries = %v, want sorted by code", got)
+	}
+	if e := c.Find("elec"); e == nil || e.Name != "Electrician" {
+		t.Errorf("Find(elec) = %+v, want the electrician (case-insensitive)", e)
+	}
+	c.Set(&Entry{Code: "ELEC", Kind: KindHuman, Name: "Senior electrician", Price: *unit.NewPrice(50, "EUR"), Per: unit.DurationUnitHour})
+	if len(c.Entries) != 3 || c.Find("ELEC").Price.Value != 50 {
+		t.Errorf("Set with an existing code should replace the entry, got %d entries", len(c.Entries))
+	}
+	if !c.Remove("CEM-25") || c.Remove("CEM-25") || c.Find("CEM-25") != nil {
+		t.Error("Remove should delete the entry once")
+	}
+}
+
+func TestCatalog_Search(t *testing.T) {
+	c := buildTestCatalog()
+	if got := c.Search("acme"); len(got) != 1 || got[0].Code != "SCR-440" {
+		t.Errorf("Search(acme) = %v, want the screws by supplier", got)
+	}
+	if got := c.Search("cem"); len(got) != 1 || got[0].Code != "CEM-25" {
+		t.Errorf("Search(cem) = %v, want the cement", got)
+	}
+	if got := c.Search(""); len(got) != 3 {
+		t.Errorf("Search(\"\") = %d entries, want all 3", len(got))
+	}
+	if got := c.OfKind(KindCountable, KindMeasurable); len(got) != 2 {
+		t.Errorf("OfKind(materials) = %d entries, want 2", len(got))
+	}
+}
+
+func TestCatalog_Validate(t *testing.T) {
+	if errs := buildTestCatalog().Validate(); len(errs) != 0 {
+		t.Errorf("Validate() = %v, want no errors", errs)
+	}
+	c := New()
+	c.Entries = []*Entry{
+		{Code: "A", Kind: KindMeasurable, Unit: "bag"},
+		{Code: "A", Kind: KindCountable, Per: unit.DurationUnitHour},
+		{Kind: "tool", Price: unit.Price{Value: -1}},
+		{Code: "B", Kind: KindAsset, Per: "fortnight"},
+	}
+	want := []string{"unknown unit", "rate unit", "duplicate code", "empty code", "unknown kind", "negative price", "rate unit"}
+	errs := c.Validate()
+	if len(errs) != len(want) {
+		t.Fatalf("Validate() = %v, want %d errors", errs, len(want))
+	}
+	for i, w := range want {
+		if !strings.Contains(errs[i].Error(), w) {
+			t.Errorf("error %d = %q, want %q", i, errs[i], w)
+		}
+	}
+}
+
+func TestCatalog_RoundTrip(t *testing.T) {
+	c := buildTestCatalog()
+	for _, format := range []string{"json", "yaml"} {
+		var buf bytes.Buffer
+		var err error
+		if format == "json" {
+			err = c.WriteJSON(&buf)
+		} else {
+			err = c.WriteYAML(&buf)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got *Catalog
+		if format == "json" {
+			got, err = ReadJSON(&buf)
+		} else {
+			got, err = ReadYAML(&buf)
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		cement := got.Find("CEM-25")
+		if len(got.Entries) != 3 || cement == nil || cement.Unit != unit.UnitKilogram || cement.PackSize != 25 || cement.Price.Value != 0.3 {
+			t.Errorf("%s round trip: cement = %+v", format, cement)
+		}
+		if got.Version != CatalogVersion {
+			t.Errorf("%s round trip: version = %q", format, got.Version)
+		}
+	}
+}
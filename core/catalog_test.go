@@ -0,0 +1,120 @@
+package core
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"explosio/core/catalog"
+	"explosio/core/material"
+	"explosio/core/resource"
+	"explosio/core/resource/asset"
+	"explosio/core/unit"
+)
+
+func buildTestCatalog() *catalog.Catalog {
+	c := catalog.New()
+	c.Set(&catalog.Entry{Code: "SCR-440", Kind: catalog.KindCountable, Name: "Screws 4x40", Supplier: "Acme", Price: *unit.NewPrice(0.1, "EUR"), PackSize: 100})
+	c.Set(&catalog.Entry{Code: "CEM-25", Kind: catalog.KindMeasurable, Name: "Cement", Price: *unit.NewPrice(0.3, "EUR"), Unit: unit.UnitKilogram, PackSize: 25})
+	c.Set(&catalog.Entry{Code: "ELEC", Kind: catalog.KindHuman, Name: "Electrician", Price: *unit.NewPrice(320, "EUR"), Per: unit.DurationUnitDay})
+	c.Set(&catalog.Entry{Code: "MIXER", Kind: catalog.KindAsset, Name: "Concrete mixer", Price: *unit.NewPrice(150, "EUR")})
+	return c
+}
+
+func TestProject_ApplyCatalog(t *testing.T) {
+	root, plaster, tiling := buildLinkTestTree()
+	screws := material.NewCountableMaterial("", "", unit.Price{}, 250)
+	screws.Code = "SCR-440"
+	tiling.AddCountableMaterial(screws)
+	cement := material.NewMeasurableMaterial("Grey cement", "", unit.Price{}, *unit.NewMeasurableQuantity(40000, unit.UnitGram))
+	cement.Code = "cem-25"
+	plaster.AddMeasurableMaterial(cement)
+	electrician := newElectrician()
+	electrician.Duration = *unit.NewDuration(12, unit.DurationUnitHour)
+	electrician.Code = "ELEC"
+	plaster.AddHumanResource(electrician)
+	mixer := asset.NewAsset("Mixer", "", unit.Price{}, *unit.NewDuration(2, unit.DurationUnitDay))
+	mixer.Code = "MIXER"
+	plaster.AddAsset(mixer)
+
+	proj := NewProject(root)
+	working := unit.WorkingTimeConversion(8, 5)
+	proj.Conversion = &working
+	pooled := resource.NewResource("Mario", "electrician", unit.Price{})
+	pooled.Code = "ELEC"
+	proj.Resources = []*resource.Resource{pooled}
+
+	if problems := proj.ApplyCatalog(buildTestCatalog()); len(problems) != 0 {
+		t.Fatalf("ApplyCatalog() problems = %v, want none", problems)
+	}
+	// 250 screws in boxes of 100: 300 at 0.10 EUR.
+	if screws.Name != "Screws 4x40" || screws.Supplier != "Acme" || screws.PackSize != 100 || screws.CalculatePrice() != 30 {
+		t.Errorf("screws = %+v, want catalog name, supplier, pack of 100 and 30 EUR", screws)
+	}
+	// 40 kg in 25 kg bags: 50 kg at 0.30 EUR/kg; the project name is kept.
+	if cement.Name != "Grey cement" || cement.PriceUnit != unit.UnitKilogram || cement.PackSize != 25000 || cement.CalculatePrice() != 15 {
+		t.Errorf("cement = %+v (price %v), want 25000 g packs priced per kg for 15 EUR", cement, cement.CalculatePrice())
+	}
+	// 12 working hours = 1.5 days at 320 EUR/day.
+	if electrician.Price.Value != 480 {
+		t.Errorf("electrician price = %v, want 480", electrician.Price.Value)
+	}
+	if mixer.Price.Value != 150 {
+		t.Errorf("mixer price = %v, want the fixed 150", mixer.Price.Value)
+	}
+	if pooled.Rate.Value != 40 || pooled.Rate.Currency != "EUR" {
+		t.Errorf("pool rate = %+v, want 40 EUR per hour", pooled.Rate)
+	}
+}
+
+func TestProject_ApplyCatalogProblems(t *testing.T) {
+	root, plaster, tiling := buildLinkTestTree()
+	unknown := material.NewCountableMaterial("Tiles", "", *unit.NewPrice(2, "EUR"), 10)
+	unknown.Code = "TILE-1"
+	tiling.AddCountableMaterial(unknown)
+	wrongKind := material.NewCountableMaterial("Cement", "", *unit.NewPrice(5, "EUR"), 2)
+	wrongKind.Code = "CEM-25"
+	plaster.AddCountableMaterial(wrongKind)
+	litres := material.NewMeasurableMaterial("Cement", "", *unit.NewPrice(1, "EUR"), *unit.NewMeasurableQuantity(3, unit.UnitLiter))
+	litres.Code = "CEM-25"
+	plaster.AddMeasurableMaterial(litres)
+	proj := NewProject(root)
+	pooled := resource.NewResource("Mixer", "", *unit.NewPrice(9, "EUR"))
+	pooled.Kind = resource.KindAsset
+	pooled.Code = "MIXER"
+	proj.Resources = []*resource.Resource{pooled}
+
+	problems := proj.ApplyCatalog(buildTestCatalog())
+	want := []string{"is measurable, not countable", "not compatible", "not found", "no rate unit"}
+	if len(problems) != len(want) {
+		t.Fatalf("problems = %v, want %d", problems, len(want))
+	}
+	for i, w := range want {
+		if !strings.Contains(problems[i].Error(), w) {
+			t.Errorf("problem %d = %q, want %q", i, problems[i], w)
+		}
+	}
+	if unknown.Price.Value != 2 || wrongKind.Price.Value != 5 || litres.Price.Value != 1 || pooled.Rate.Value != 9 {
+		t.Error("unresolved items should keep their own prices")
+	}
+}
+
+func TestProject_CatalogCodesRoundTrip(t *testing.T) {
+	root, plaster, _ := buildLinkTestTree()
+	electrician := newElectrician()
+	electrician.Code = "ELEC"
+	plaster.AddHumanResource(electrician)
+	proj := NewProject(root)
+	proj.Catalog = "prices.yaml"
+	var buf bytes.Buffer
+	if err := proj.WriteYAML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadYAML(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Catalog != "prices.yaml" || got.Root.Activities[0].HumanResources[0].Code != "ELEC" {
+		t.Errorf("catalog = %q, code = %q after round trip", got.Catalog, got.Root.Activities[0].HumanResources[0].Code)
+	}
+}
@@ -33,3 +33,20 @@ func TestActivity_Clone(t *testing.T) {
 		t.Error("Clone's child should be a different pointer")
 	}
 }
+
+func TestActivity_Clone_RemapsDependsOn(t *testing.T) {
+	root, pipes, tiles := buildDependencyTestTree()
+	clone := root.Clone()
+
+	clonedTiles := clone.FindByID(tiles.ID)
+	clonedPipes := clone.FindByID(pipes.ID)
+	if clonedTiles == nil || clonedPipes == nil {
+		t.Fatal("clone should keep activity IDs")
+	}
+	if clonedTiles == tiles {
+		t.Fatal("clone should not share activities with the original")
+	}
+	if len(clonedTiles.DependsOn) != 1 || clonedTiles.DependsOn[0].Activity != clonedPipes {
+		t.Errorf("cloned Tiles should depend on cloned Pipes, got %v", clonedTiles.DependsOn)
+	}
+}
@@ -0,0 +1,136 @@
+package core
+
+import (
+	"fmt"
+
+	"explosio/core/unit"
+)
+
+// ConstraintType is a scheduling constraint on an activity.
+type ConstraintType string
+
+const (
+	AsSoonAsPossible    ConstraintType = "ASAP" // Default: start at the early start
+	AsLateAsPossible    ConstraintType = "ALAP" // Start at the late start (within total float)
+	StartNoEarlierThan  ConstraintType = "SNET" // Start on or after the date
+	StartNoLaterThan    ConstraintType = "SNLT" // Start on or before the date
+	FinishNoEarlierThan ConstraintType = "FNET" // Finish on or after the end of the date
+	FinishNoLaterThan   ConstraintType = "FNLT" // Finish on or before the end of the date
+	MustStartOn         ConstraintType = "MSO"  // Start exactly on the date
+	MustFinishOn        ConstraintType = "MFO"  // Finish exactly at the end of the date
+)
+
+// Constraint restricts when an activity can be scheduled. Date is required for all types except ASAP and ALAP.
+// Date constraints need a project start date to be converted to schedule hours: they are honored by the Project
+// scheduling methods (when Project.Start is set) and by ComputeSchedule; otherwise they are ignored.
+type Constraint struct {
+	Type ConstraintType
+	Date *unit.Date `json:",omitempty" yaml:",omitempty"`
+}
+
+// SetConstraint sets the scheduling constraint of the activity. The date is ignored for ASAP and ALAP.
+func (a *Activity) SetConstraint(constraintType ConstraintType, date unit.Date) {
+	c := &Constraint{Type: constraintType}
+	if constraintType.needsDate() {
+		c.Date = &date
+	}
+	a.Constraint = c
+}
+
+// needsDate returns true if the constraint type requires a date.
+func (t ConstraintType) needsDate() bool {
+	return t != "" && t != AsSoonAsPossible && t != AsLateAsPossible
+}
+
+// IsValidConstraintType returns true if t is a known constraint type (or empty, meaning ASAP).
+func IsValidConstraintType(t ConstraintType) bool {
+	switch t {
+	case "", AsSoonAsPossible, AsLateAsPossible, StartNoEarlierThan, StartNoLaterThan,
+		FinishNoEarlierThan, FinishNoLaterThan, MustStartOn, MustFinishOn:
+		return true
+	}
+	return false
+}
+
+// constraintHours returns the constraint type and its date converted to schedule hours
+// (beginning of the date for start constraints, end of the date for finish constraints).
+// ok is false if the activity has no applicable date constraint.
+func (o scheduleOptions) constraintHours(a *Activity) (ConstraintType, float64, bool) {
+	c := a.Constraint
+	if c == nil || !c.Type.needsDate() || c.Date == nil || o.start == nil {
+		return "", 0, false
+	}
+	switch c.Type {
+	case FinishNoEarlierThan, FinishNoLaterThan, MustFinishOn:
+		return c.Type, o.hoursAtEndOf(*c.Date), true
+	default:
+		return c.Type, o.hoursAt(*c.Date), true
+	}
+}
+
+// applyEarlyConstraint adjusts an early start (from predecessors) to the activity's constraint.
+func (o scheduleOptions) applyEarlyConstraint(a *Activity, es, dur float64) float64 {
+	t, h, ok := o.constraintHours(a)
+	if !ok {
+		return es
+	}
+	switch t {
+	case StartNoEarlierThan:
+		if h > es {
+			es = h
+		}
+	case FinishNoEarlierThan:
+		if h-dur > es {
+			es = h - dur
+		}
+	case MustStartOn:
+		es = h
+	case MustFinishOn:
+		es = h - dur
+	}
+	return es
+}
+
+// applyLateConstraint adjusts a late finish (from successors) to the activity's constraint.
+func (o scheduleOptions) applyLateConstraint(a *Activity, lf, dur float64) float64 {
+	t, h, ok := o.constraintHours(a)
+	if !ok {
+		return lf
+	}
+	switch t {
+	case StartNoLaterThan:
+		if h+dur < lf {
+			lf = h + dur
+		}
+	case FinishNoLaterThan:
+		if h < lf {
+			lf = h
+		}
+	case MustStartOn:
+		lf = h + dur
+	case MustFinishOn:
+		lf = h
+	}
+	return lf
+}
+
+// hasConstraints returns true if any activity in the tree has a constraint other than ASAP.
+func (a *Activity) hasConstraints() bool {
+	if a.Constraint != nil && a.Constraint.Type != "" && a.Constraint.Type != AsSoonAsPossible {
+		return true
+	}
+	for _, child := range a.Activities {
+		if child.hasConstraints() {
+			return true
+		}
+	}
+	return false
+}
+
+// String formats the constraint (e.g. "SNET 2026-03-02").
+func (c *Constraint) String() string {
+	if c.Date == nil {
+		return string(c.Type)
+	}
+	return fmt.Sprintf("%s %s", c.Type, c.Date.String())
+}
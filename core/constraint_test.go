@@ -0,0 +1,148 @@
+package core
+
+import (
+	"bytes"
+	"explosio/core/unit"
+	"testing"
+	"time"
+)
+
+// buildConstraintTestProject returns a project starting on Monday 2026-03-02 with Root (0d) and two
+// parallel children: Plaster (4d) and Tiling (3d). Calendar time: 1 day = 24h.
+func buildConstraintTestProject() (*Project, *Activity, *Activity) {
+	root, plaster, tiling := buildLinkTestTree()
+	p := NewProject(root)
+	start := unit.NewDate(2026, time.March, 2)
+	p.Start = &start
+	return p, plaster, tiling
+}
+
+func TestConstraint_StartNoEarlierThan(t *testing.T) {
+	p, plaster, tiling := buildConstraintTestProject()
+	tiling.SetConstraint(StartNoEarlierThan, unit.NewDate(2026, time.March, 4))
+
+	m := p.CalculateSlack()
+	if got := m[tiling].ES; got != 48 {
+		t.Errorf("Tiling ES = %v, want 48", got)
+	}
+	// Tiling now ends at 120h, after Plaster (96h): Plaster has 24h slack, Tiling is critical.
+	if got := m[plaster].Slack; got != 24 {
+		t.Errorf("Plaster slack = %v, want 24", got)
+	}
+	if got := m[tiling].Slack; got != 0 {
+		t.Errorf("Tiling slack = %v, want 0", got)
+	}
+}
+
+func TestConstraint_MustFinishOnAndDeadline(t *testing.T) {
+	p, plaster, tiling := buildConstraintTestProject()
+	tiling.SetConstraint(MustFinishOn, unit.NewDate(2026, time.March, 5))
+	deadline := unit.NewDate(2026, time.March, 4)
+	p.Deadline = &deadline
+
+	m := p.CalculateSlack()
+	if got := m[tiling].EF; got != 96 {
+		t.Errorf("Tiling EF = %v, want 96", got)
+	}
+	// The deadline (end of March 4 = 72h) is one day before Plaster finishes: negative slack, not clamped.
+	// MFO is a hard constraint: Tiling stays on its date (slack 0) even though it ends after the deadline.
+	if got := m[plaster].Slack; got != -24 {
+		t.Errorf("Plaster slack = %v, want -24", got)
+	}
+	if got := m[tiling].Slack; got != 0 {
+		t.Errorf("Tiling slack = %v, want 0", got)
+	}
+
+	r := p.Validate()
+	if !r.Valid() {
+		t.Fatalf("unexpected errors: %v", r.Errors)
+	}
+	if len(r.Warnings) == 0 {
+		t.Error("expected warnings for negative slack and missed deadline")
+	}
+}
+
+func TestConstraint_AsLateAsPossible(t *testing.T) {
+	p, plaster, tiling := buildConstraintTestProject()
+	tiling.SetConstraint(AsLateAsPossible, unit.Date{})
+
+	m := p.CalculateSlack()
+	if got := m[tiling].ES; got != 24 {
+		t.Errorf("Tiling ES = %v, want 24", got)
+	}
+	if got := m[tiling].Slack; got != 0 {
+		t.Errorf("Tiling slack = %v, want 0", got)
+	}
+	if got := m[plaster].ES; got != 0 {
+		t.Errorf("Plaster ES = %v, want 0", got)
+	}
+}
+
+func TestConstraint_WithCalendar(t *testing.T) {
+	p, _, tiling := buildConstraintTestProject()
+	p.Calendar = unit.NewCalendar()
+	// Monday 2026-03-09 is 5 working days after the start: 5 * 24 CPM hours.
+	tiling.SetConstraint(StartNoEarlierThan, unit.NewDate(2026, time.March, 9))
+
+	m := p.CalculateSlack()
+	if got := m[tiling].ES; got != 120 {
+		t.Errorf("Tiling ES = %v, want 120", got)
+	}
+	sched := p.ComputeSchedule(*p.Start)
+	if got := sched[tiling].StartDate.String(); got != "2026-03-09" {
+		t.Errorf("Tiling start = %s, want 2026-03-09", got)
+	}
+}
+
+func TestConstraint_IgnoredWithoutStart(t *testing.T) {
+	p, _, tiling := buildConstraintTestProject()
+	p.Start = nil
+	tiling.SetConstraint(MustStartOn, unit.NewDate(2026, time.March, 4))
+
+	if got := p.CalculateSlack()[tiling].ES; got != 0 {
+		t.Errorf("Tiling ES = %v, want 0", got)
+	}
+	if r := p.Validate(); len(r.Warnings) == 0 {
+		t.Error("expected a warning for the ignored constraint")
+	}
+}
+
+func TestConstraint_Validation(t *testing.T) {
+	p, plaster, tiling := buildConstraintTestProject()
+	plaster.Constraint = &Constraint{Type: "XYZ"}
+	tiling.Constraint = &Constraint{Type: FinishNoLaterThan}
+
+	r := p.Root.Validate()
+	if len(r.Errors) != 2 {
+		t.Errorf("expected 2 errors (unknown type, missing date), got %v", r.Errors)
+	}
+}
+
+func TestConstraint_RoundTrip(t *testing.T) {
+	p, _, tiling := buildConstraintTestProject()
+	tiling.SetConstraint(FinishNoLaterThan, unit.NewDate(2026, time.March, 6))
+	deadline := unit.NewDate(2026, time.March, 10)
+	p.Deadline = &deadline
+
+	var buf bytes.Buffer
+	if err := p.WriteYAML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ReadYAML(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Start == nil || loaded.Start.String() != "2026-03-02" {
+		t.Errorf("Start = %v, want 2026-03-02", loaded.Start)
+	}
+	if loaded.Deadline == nil || loaded.Deadline.String() != "2026-03-10" {
+		t.Errorf("Deadline = %v, want 2026-03-10", loaded.Deadline)
+	}
+	c := loaded.Root.Activities[1].Constraint
+	if c == nil || c.Type != FinishNoLaterThan || c.Date == nil || c.Date.String() != "2026-03-06" {
+		t.Errorf("Constraint = %v, want FNLT 2026-03-06", c)
+	}
+	if loaded.Root.Activities[0].Constraint != nil {
+		t.Error("Plaster should have no constraint")
+	}
+}
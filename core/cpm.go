@@ -1,40 +1,110 @@
 // Package core provides CPM (Critical Path Method) with explicit dependencies.
 package core
 
-// buildCPMGraph builds predecessor map for all activities. Recursively includes all descendants.
-// Predecessors = parent (implicit) + DependsOn (explicit), deduplicated.
-func (a *Activity) buildCPMGraph(parent *Activity, all map[*Activity]bool, preds map[*Activity][]*Activity) {
+import "explosio/core/unit"
+
+// scheduleOptions carries the project settings used by the CPM passes.
+type scheduleOptions struct {
+	conv     unit.DurationConversion // Duration to hours conversion
+	cal      *unit.Calendar          // Working calendar for dates (nil = continuous time)
+	start    *unit.Date              // Project start: needed to convert constraint and deadline dates to hours
+	deadline *unit.Date              // Project deadline: the project must finish by the end of this date
+	status   *unit.Date              // Status date: progress is recorded up to the end of this date (see progress.go)
+
+	durations map[*Activity]float64 // Duration overrides in hours (e.g. PERT expected durations)
+}
+
+// defaultScheduleOptions returns the options used by Activity methods: the default conversion (unit.DefaultConversion),
+// the same the resource builders use to price rates.
+func defaultScheduleOptions() scheduleOptions {
+	return scheduleOptions{conv: unit.DefaultConversion()}
+}
+
+// hours converts a duration to hours with the options' conversion policy.
+func (o scheduleOptions) hours(d unit.Duration) float64 {
+	return d.ToHoursWith(o.conv)
+}
+
+// activityHours returns the duration of the activity in hours: the override if set, else its Duration.
+func (o scheduleOptions) activityHours(a *Activity) float64 {
+	if h, ok := o.durations[a]; ok {
+		return h
+	}
+	return o.hours(a.Duration)
+}
+
+// delayHours returns the leveling delay of the activity in hours (0 if none).
+func (o scheduleOptions) delayHours(a *Activity) float64 {
+	if a.LevelingDelay == nil {
+		return 0
+	}
+	return o.hours(*a.LevelingDelay)
+}
+
+// hoursAt returns the CPM hours from the project start to the beginning of date d.
+// With a calendar only working time counts, scaled so that a working day equals conv.HoursPerDay.
+func (o scheduleOptions) hoursAt(d unit.Date) float64 {
+	if o.start == nil {
+		return 0
+	}
+	if o.cal != nil && o.cal.HoursPerDay > 0 && o.conv.HoursPerDay > 0 {
+		return o.cal.WorkingHoursBetween(*o.start, d) * o.conv.HoursPerDay / o.cal.HoursPerDay
+	}
+	return d.StartOfDay().Time.Sub(o.start.StartOfDay().Time).Hours()
+}
+
+// hoursAtEndOf returns the CPM hours from the project start to the end of date d.
+func (o scheduleOptions) hoursAtEndOf(d unit.Date) float64 {
+	return o.hoursAt(d.StartOfDay().AddDays(1))
+}
+
+// deadlineHours returns the project deadline in CPM hours; ok is false if there is no deadline or no start.
+func (o scheduleOptions) deadlineHours() (float64, bool) {
+	if o.deadline == nil || o.start == nil {
+		return 0, false
+	}
+	return o.hoursAtEndOf(*o.deadline), true
+}
+
+// slackEpsilon is the tolerance used to compare slack values (hours).
+const slackEpsilon = 1e-9
+
+// buildCPMGraph builds the predecessor links for all activities. Recursively includes all descendants.
+// Predecessors = parent (implicit finish-to-start) + DependsOn (explicit, typed with lag), deduplicated.
+func (a *Activity) buildCPMGraph(parent *Activity, all map[*Activity]bool, preds map[*Activity][]cpmLink, opts scheduleOptions) {
 	all[a] = true
-	seen := make(map[*Activity]bool)
-	var p []*Activity
-	if parent != nil && !seen[parent] {
-		seen[parent] = true
-		p = append(p, parent)
+	seen := make(map[cpmLink]bool)
+	var p []cpmLink
+	if parent != nil {
+		link := cpmLink{pred: parent, typ: FinishToStart}
+		seen[link] = true
+		p = append(p, link)
 	}
 	for _, dep := range a.DependsOn {
-		if !seen[dep] {
-			seen[dep] = true
-			p = append(p, dep)
+		link := cpmLink{pred: dep.Activity, typ: dep.kind(), lag: opts.hours(dep.Lag)}
+		if !seen[link] {
+			seen[link] = true
+			p = append(p, link)
 		}
 	}
 	preds[a] = p
 
 	for _, child := range a.Activities {
-		child.buildCPMGraph(a, all, preds)
+		child.buildCPMGraph(a, all, preds, opts)
 	}
 }
 
 // topoOrder returns activities in topological order (all predecessors before each activity).
-func topoOrder(all map[*Activity]bool, preds map[*Activity][]*Activity) []*Activity {
+func topoOrder(all map[*Activity]bool, preds map[*Activity][]cpmLink) []*Activity {
 	inDegree := make(map[*Activity]int)
 	for a := range all {
 		inDegree[a] = 0
 	}
 	for a, predList := range preds {
 		seen := make(map[*Activity]bool)
-		for _, p := range predList {
-			if all[p] && !seen[p] {
-				seen[p] = true
+		for _, l := range predList {
+			if all[l.pred] && !seen[l.pred] {
+				seen[l.pred] = true
 				inDegree[a]++
 			}
 		}
@@ -53,8 +123,8 @@ func topoOrder(all map[*Activity]bool, preds map[*Activity][]*Activity) []*Activ
 		queue = queue[1:]
 		order = append(order, a)
 		for other := range all {
-			for _, p := range preds[other] {
-				if p == a {
+			for _, l := range preds[other] {
+				if l.pred == a {
 					inDegree[other]--
 					if inDegree[other] == 0 {
 						queue = append(queue, other)
@@ -67,30 +137,59 @@ func topoOrder(all map[*Activity]bool, preds map[*Activity][]*Activity) []*Activ
 	return order
 }
 
+// cpmSuccessor is a successor of an activity in the CPM graph, with the link that connects them.
+type cpmSuccessor struct {
+	act  *Activity
+	link cpmLink
+}
+
+// earlyDates applies the forward-pass rules to es, the earliest start allowed by the predecessors: date constraint,
+// leveling delay, ALAP floor (not for started work) and, when progress is tracked, actual dates and the status date.
+// It returns the early start and finish.
+func (o scheduleOptions) earlyDates(act *Activity, es, hours float64, floor map[*Activity]float64) (float64, float64) {
+	es = o.applyEarlyConstraint(act, es, hours) + o.delayHours(act)
+	if f, ok := floor[act]; ok && f > es && !act.IsStarted() {
+		es = f
+	}
+	ef := es + hours
+	if o.tracksProgress() {
+		es = o.progressStart(act, es)
+		ef = o.progressFinish(act, es)
+	}
+	return es, ef
+}
+
 // cpmForwardBackward runs full CPM with dependencies. Returns slack map and project end.
-func (a *Activity) cpmForwardBackward() (map[*Activity]SlackInfo, float64) {
+func (a *Activity) cpmForwardBackward(opts scheduleOptions) (map[*Activity]SlackInfo, float64) {
 	all := make(map[*Activity]bool)
-	preds := make(map[*Activity][]*Activity)
-	a.buildCPMGraph(nil, all, preds)
+	preds := make(map[*Activity][]cpmLink)
+	a.buildCPMGraph(nil, all, preds, opts)
 
 	order := topoOrder(all, preds)
 	if len(order) == 0 {
 		return nil, 0
 	}
 
-	// Forward pass
+	// Forward pass. floor holds a minimum early start per activity (used to place ALAP activities).
 	m := make(map[*Activity]SlackInfo)
-	for _, act := range order {
-		myHours := act.Duration.ToHours()
-		es := 0.0
-		for _, p := range preds[act] {
-			if info, ok := m[p]; ok && info.EF > es {
-				es = info.EF
+	forward := func(floor map[*Activity]float64) {
+		for _, act := range order {
+			myHours := opts.activityHours(act)
+			es := 0.0
+			for _, l := range preds[act] {
+				if info, ok := m[l.pred]; ok {
+					if s := l.earliestStart(info.ES, info.EF, myHours); s > es {
+						es = s
+					}
+				}
 			}
+			es, ef := opts.earlyDates(act, es, myHours, floor)
+			info := m[act]
+			info.ES, info.EF = es, ef
+			m[act] = info
 		}
-		ef := es + myHours
-		m[act] = SlackInfo{ES: es, EF: ef}
 	}
+	forward(nil)
 
 	projectEnd := 0.0
 	for _, info := range m {
@@ -99,52 +198,94 @@ func (a *Activity) cpmForwardBackward() (map[*Activity]SlackInfo, float64) {
 		}
 	}
 
-	// Build successors: for each activity, who has it as predecessor?
-	succs := make(map[*Activity][]*Activity)
+	// Build successors: for each activity, who has it as predecessor (and through which link)?
+	succs := make(map[*Activity][]cpmSuccessor)
 	for act, predList := range preds {
-		for _, p := range predList {
-			succs[p] = append(succs[p], act)
+		for _, l := range predList {
+			succs[l.pred] = append(succs[l.pred], cpmSuccessor{act: act, link: l})
 		}
 	}
 
-	// Backward pass (reverse order)
+	// Backward pass (reverse order). Activities without successors finish by the project end or the deadline.
+	end := projectEnd
+	if d, ok := opts.deadlineHours(); ok && d < end {
+		end = d
+	}
 	for i := len(order) - 1; i >= 0; i-- {
 		act := order[i]
 		info := m[act]
-		myHours := act.Duration.ToHours()
-
-		var lf float64
-		if len(succs[act]) == 0 {
-			lf = projectEnd
-		} else {
-			lf = projectEnd
-			for _, s := range succs[act] {
-				if sinfo, ok := m[s]; ok && sinfo.LS < lf {
-					lf = sinfo.LS
+		myHours := info.EF - info.ES // differs from the duration for activities with progress
+
+		lf := end
+		for _, s := range succs[act] {
+			if sinfo, ok := m[s.act]; ok {
+				d := opts.delayHours(s.act)
+				if f := s.link.latestFinish(sinfo.LS-d, sinfo.LF-d, myHours); f < lf {
+					lf = f
 				}
 			}
 		}
-		ls := lf - myHours
-		slack := ls - info.ES
-		if slack < 0 {
-			slack = 0
+		lf = opts.applyLateConstraint(act, lf, myHours)
+		info.LS, info.LF = lf-myHours, lf
+		m[act] = info
+	}
+
+	// ALAP activities start at their late start; successors are pushed accordingly.
+	floor := make(map[*Activity]float64)
+	for act, info := range m {
+		if act.Constraint != nil && act.Constraint.Type == AsLateAsPossible {
+			floor[act] = info.LS
+		}
+	}
+	if len(floor) > 0 {
+		forward(floor)
+	}
+
+	// Floats are not clamped: a negative value means a constraint or the deadline cannot be met.
+	for act, info := range m {
+		myHours := info.EF - info.ES
+		info.TotalFloat = info.LS - info.ES
+		info.Slack = info.TotalFloat
+		// Free float: predecessors at early dates, successors at early dates.
+		info.FreeFloat = successorGap(act, succs, m, info.ES, myHours, projectEnd, opts)
+		// Independent float: predecessors at late dates, successors at early dates.
+		lateStart := info.ES
+		for _, l := range preds[act] {
+			if pinfo, ok := m[l.pred]; ok {
+				if s := l.earliestStart(pinfo.LS, pinfo.LF, myHours); s > lateStart {
+					lateStart = s
+				}
+			}
 		}
-		m[act] = SlackInfo{
-			ES:    info.ES,
-			EF:    info.EF,
-			LS:    ls,
-			LF:    lf,
-			Slack: slack,
+		info.IndependentFloat = successorGap(act, succs, m, lateStart, myHours, projectEnd, opts)
+		if info.IndependentFloat < 0 {
+			info.IndependentFloat = 0
 		}
+		info.InterferingFloat = info.TotalFloat - info.FreeFloat
+		m[act] = info
 	}
 	return m, projectEnd
 }
 
-// criticalPathFromSlack returns activities with slack 0, ordered by ES (early start).
-func criticalPathFromSlack(m map[*Activity]SlackInfo) []*Activity {
+// successorGap returns how long act, starting at es, can slip before it delays the early start of a successor
+// (or the project end, for an activity without successors). A successor's leveling delay is not float:
+// it is counted from the successor's predecessors.
+func successorGap(act *Activity, succs map[*Activity][]cpmSuccessor, m map[*Activity]SlackInfo, es, hours, projectEnd float64, opts scheduleOptions) float64 {
+	gap := projectEnd - (es + hours)
+	for _, s := range succs[act] {
+		bound := s.link.earliestStart(es, es+hours, opts.activityHours(s.act))
+		if g := m[s.act].ES - opts.delayHours(s.act) - bound; g < gap {
+			gap = g
+		}
+	}
+	return gap
+}
+
+// criticalPathFromSlack returns activities with total float up to maxFloat, ordered by ES (early start).
+func criticalPathFromSlack(m map[*Activity]SlackInfo, maxFloat float64) []*Activity {
 	var path []*Activity
 	for a, info := range m {
-		if info.Slack == 0 {
+		if info.TotalFloat <= maxFloat+slackEpsilon {
 			path = append(path, a)
 		}
 	}
@@ -159,6 +300,29 @@ func criticalPathFromSlack(m map[*Activity]SlackInfo) []*Activity {
 	return path
 }
 
+// lowestFloat returns the lowest total float in the map (0 if empty).
+func lowestFloat(m map[*Activity]SlackInfo) float64 {
+	lowest := 0.0
+	first := true
+	for _, info := range m {
+		if first || info.TotalFloat < lowest {
+			lowest = info.TotalFloat
+			first = false
+		}
+	}
+	return lowest
+}
+
+// usesFullCPM returns true if the tree needs the full CPM (explicit dependencies, constraints, leveling delays,
+// a deadline or progress tracking) instead of the tree-based passes.
+func (a *Activity) usesFullCPM(opts scheduleOptions) bool {
+	if a.hasExplicitDependencies() || a.hasConstraints() || a.hasLevelingDelays() || opts.tracksProgress() {
+		return true
+	}
+	_, ok := opts.deadlineHours()
+	return ok
+}
+
 // hasExplicitDependencies returns true if any activity in the tree has DependsOn.
 func (a *Activity) hasExplicitDependencies() bool {
 	if len(a.DependsOn) > 0 {
@@ -47,3 +47,64 @@ func TestCalculateCriticalPath_WithDependsOn(t *testing.T) {
 		t.Errorf("D should have positive slack, got %.2f", info.Slack)
 	}
 }
+
+// buildLinkTestTree returns Root (0d) with two parallel children: Plaster (4d) and Tiling (3d).
+func buildLinkTestTree() (*Activity, *Activity, *Activity) {
+	root := NewActivity("Root", "", *unit.NewDuration(0, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	plaster := NewActivity("Plaster", "", *unit.NewDuration(4, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	tiling := NewActivity("Tiling", "", *unit.NewDuration(3, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	root.AddActivity(plaster)
+	root.AddActivity(tiling)
+	return root, plaster, tiling
+}
+
+func TestCPM_DependencyTypes(t *testing.T) {
+	day := 24.0
+	tests := []struct {
+		name    string
+		typ     DependencyType
+		lagDays float64
+		wantES  float64 // Tiling early start (hours)
+		wantEnd float64 // project end (hours)
+	}{
+		{"FS no lag", FinishToStart, 0, 4 * day, 7 * day},
+		{"FS lag 1d", FinishToStart, 1, 5 * day, 8 * day},
+		{"FS lead 2d", FinishToStart, -2, 2 * day, 5 * day},
+		{"SS lag 2d", StartToStart, 2, 2 * day, 5 * day},
+		{"FF no lag", FinishToFinish, 0, 1 * day, 4 * day},
+		{"FF lag 2d", FinishToFinish, 2, 3 * day, 6 * day},
+		{"SF lag 5d", StartToFinish, 5, 2 * day, 5 * day},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, plaster, tiling := buildLinkTestTree()
+			tiling.AddDependency(plaster, tt.typ, *unit.NewDuration(tt.lagDays, unit.DurationUnitDay))
+			m, end := root.cpmForwardBackward(defaultScheduleOptions())
+			if got := m[tiling].ES; got != tt.wantES {
+				t.Errorf("Tiling ES = %v, want %v", got, tt.wantES)
+			}
+			if end != tt.wantEnd {
+				t.Errorf("project end = %v, want %v", end, tt.wantEnd)
+			}
+			// Backward pass must honor the link: Plaster's late dates leave no room to violate it.
+			if m[plaster].LF > end {
+				t.Errorf("Plaster LF = %v exceeds project end %v", m[plaster].LF, end)
+			}
+		})
+	}
+}
+
+func TestCPM_StartToStartBackwardPass(t *testing.T) {
+	// Tiling (1d) starts 2 days after Plaster (4d) starts: Tiling ends at day 3, Plaster at day 4.
+	// Tiling can slip 1 day; Plaster cannot start later without delaying Tiling's latest start.
+	root, plaster, tiling := buildLinkTestTree()
+	tiling.Duration = *unit.NewDuration(1, unit.DurationUnitDay)
+	tiling.AddDependency(plaster, StartToStart, *unit.NewDuration(2, unit.DurationUnitDay))
+	m, _ := root.cpmForwardBackward(defaultScheduleOptions())
+	if got := m[tiling].Slack; got != 24 {
+		t.Errorf("Tiling slack = %v, want 24", got)
+	}
+	if got := m[plaster].Slack; got != 0 {
+		t.Errorf("Plaster slack = %v, want 0", got)
+	}
+}
@@ -0,0 +1,242 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+
+	"explosio/core/unit"
+)
+
+// CurrencyConversion converts prices into a reporting currency with the exchange rates effective on a date.
+type CurrencyConversion struct {
+	Currency string             // Reporting currency
+	Rates    unit.ExchangeRates // Exchange-rate table
+	Date     unit.Date          // Date of the rates
+}
+
+// costCategory is the CostBreakdown category of a priced item.
+type costCategory int
+
+const (
+	categoryActivity costCategory = iota
+	categoryMaterial
+	categoryHuman
+	categoryAsset
+)
+
+// pricedItem is a price of the activity's own scope with its currency and category.
+// Name and supplier identify the item for the pricing policy.
+type pricedItem struct {
+	value    unit.Money
+	currency string
+	category costCategory
+	name     string
+	supplier string
+}
+
+// ownPricedItems returns the prices of the activity's own scope (own price, materials, resources), without sub-activities.
+// Pool assignments are priced in the currency of the resource rate.
+func (a *Activity) ownPricedItems() []pricedItem {
+	items := []pricedItem{{a.Price.Money(), a.Price.Currency, categoryActivity, a.Name, ""}}
+	for _, m := range a.ComplexMaterials {
+		items = append(items, pricedItem{m.CalculateMoney(), m.Price.Currency, categoryMaterial, m.Name, m.Supplier})
+	}
+	for _, m := range a.CountableMaterials {
+		items = append(items, pricedItem{m.CalculateMoney(), m.Price.Currency, categoryMaterial, m.Name, m.Supplier})
+	}
+	for _, m := range a.MeasurableMaterials {
+		items = append(items, pricedItem{m.CalculateMoney(), m.Price.Currency, categoryMaterial, m.Name, m.Supplier})
+	}
+	for _, h := range a.HumanResources {
+		items = append(items, pricedItem{h.CalculateMoney(), h.Price.Currency, categoryHuman, h.Name, ""})
+	}
+	for _, as := range a.Assets {
+		items = append(items, pricedItem{as.CalculateMoney(), as.Price.Currency, categoryAsset, as.Name, ""})
+	}
+	for _, as := range a.Assignments {
+		if as.Resource == nil {
+			continue
+		}
+		category := categoryHuman
+		if as.Resource.IsAsset() {
+			category = categoryAsset
+		}
+		items = append(items, pricedItem{as.CalculateMoney(), as.Resource.Rate.Currency, category, as.Resource.Name, ""})
+	}
+	return items
+}
+
+// add adds value to the category of the breakdown.
+func (m *MoneyBreakdown) add(category costCategory, value unit.Money) {
+	switch category {
+	case categoryMaterial:
+		m.Materials = m.Materials.Add(value)
+	case categoryHuman:
+		m.Human = m.Human.Add(value)
+	case categoryAsset:
+		m.Assets = m.Assets.Add(value)
+	default:
+		m.Activities = m.Activities.Add(value)
+	}
+}
+
+// CostBreakdownIn returns the price breakdown of the tree with every price converted into the reporting currency.
+// Converted amounts are rounded half-even to 4 decimals and summed exactly.
+// It fails if a currency has no exchange rate on the conversion date.
+func (a *Activity) CostBreakdownIn(c CurrencyConversion) (CostBreakdown, error) {
+	mb, err := a.MoneyBreakdownIn(c)
+	if err != nil {
+		return CostBreakdown{}, err
+	}
+	return mb.Float(), nil
+}
+
+// MoneyBreakdownIn is CostBreakdownIn with exact amounts in the reporting currency.
+func (a *Activity) MoneyBreakdownIn(c CurrencyConversion) (MoneyBreakdown, error) {
+	var mb MoneyBreakdown
+	for _, act := range a.GetActivities() {
+		own, err := act.ownMoneyBreakdownIn(&c)
+		if err != nil {
+			return MoneyBreakdown{}, err
+		}
+		mb.Activities = mb.Activities.Add(own.Activities)
+		mb.Materials = mb.Materials.Add(own.Materials)
+		mb.Human = mb.Human.Add(own.Human)
+		mb.Assets = mb.Assets.Add(own.Assets)
+	}
+	return mb, nil
+}
+
+// ownMoneyBreakdownIn returns the price breakdown of the activity's own scope converted into the reporting
+// currency, or the prices as they are if c is nil (see ownMoneyBreakdown). The reports that spread or roll up
+// own costs (cash flow, earned value, baselines, workload) share it with the project totals.
+func (a *Activity) ownMoneyBreakdownIn(c *CurrencyConversion) (MoneyBreakdown, error) {
+	if c == nil {
+		return a.ownMoneyBreakdown(), nil
+	}
+	var mb MoneyBreakdown
+	for _, item := range a.ownPricedItems() {
+		if item.value.IsZero() {
+			continue
+		}
+		converted, err := c.convert(item.value, item.currency)
+		if err != nil {
+			return MoneyBreakdown{}, fmt.Errorf("%s: %w", a.Name, err)
+		}
+		mb.add(item.category, converted)
+	}
+	return mb, nil
+}
+
+// convert returns the amount in currency converted into the reporting currency, rounded half-even to 4 decimals.
+func (c *CurrencyConversion) convert(m unit.Money, currency string) (unit.Money, error) {
+	r, err := c.Rates.Rate(currency, c.Currency, c.Date)
+	if err != nil {
+		return unit.Money{}, err
+	}
+	converted := m.Mul(r, unit.RoundHalfEven)
+	converted.Currency = c.Currency
+	return converted, nil
+}
+
+// convertFloat is convert for the float amounts of the reports; it returns v unchanged if c is nil.
+func (c *CurrencyConversion) convertFloat(v float64, currency string) (float64, error) {
+	if c == nil || v == 0 {
+		return v, nil
+	}
+	m, err := c.convert(unit.NewMoney(v, currency), currency)
+	return m.Float64(), err
+}
+
+// reportCurrency returns the currency of a report: the reporting currency with a conversion, else fallback.
+func (c *CurrencyConversion) reportCurrency(fallback string) string {
+	if c == nil {
+		return fallback
+	}
+	return c.Currency
+}
+
+// CalculatePriceIn returns the total price of the tree converted into the reporting currency.
+func (a *Activity) CalculatePriceIn(c CurrencyConversion) (float64, error) {
+	mb, err := a.MoneyBreakdownIn(c)
+	if err != nil {
+		return 0, err
+	}
+	return mb.Total().Float64(), nil
+}
+
+// currencies returns the currencies used by the priced items of the tree, sorted.
+func (a *Activity) currencies() []string {
+	seen := make(map[string]bool)
+	for _, act := range a.GetActivities() {
+		for _, item := range act.ownPricedItems() {
+			if item.currency != "" {
+				seen[item.currency] = true
+			}
+		}
+	}
+	var list []string
+	for c := range seen {
+		list = append(list, c)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// ReportingCurrency returns the currency used for project totals: Currency if set, else the root activity currency.
+func (p *Project) ReportingCurrency() string {
+	if p.Currency != "" {
+		return p.Currency
+	}
+	return p.Root.Price.Currency
+}
+
+// ConversionDate returns the date of the exchange rates used for project totals: the status date, else the date
+// of the latest exchange rate, so that totals do not depend on the day they are computed.
+func (p *Project) ConversionDate() unit.Date {
+	if p.StatusDate != nil {
+		return *p.StatusDate
+	}
+	return p.ExchangeRates.LatestDate()
+}
+
+// Currencies returns the reporting currency followed by the other currencies used by prices or exchange rates, sorted.
+func (p *Project) Currencies() []string {
+	reporting := p.ReportingCurrency()
+	seen := map[string]bool{reporting: true, "": true}
+	var others []string
+	add := func(c string) {
+		if !seen[c] {
+			seen[c] = true
+			others = append(others, c)
+		}
+	}
+	for _, c := range p.Root.currencies() {
+		add(c)
+	}
+	for _, r := range p.ExchangeRates {
+		add(r.From)
+		add(r.To)
+	}
+	sort.Strings(others)
+	return append([]string{reporting}, others...)
+}
+
+// CurrencyConversion returns the conversion into currency (the reporting currency if empty) with the project
+// exchange rates effective on the given date.
+func (p *Project) CurrencyConversion(currency string, on unit.Date) CurrencyConversion {
+	if currency == "" {
+		currency = p.ReportingCurrency()
+	}
+	return CurrencyConversion{Currency: currency, Rates: p.ExchangeRates, Date: on}
+}
+
+// CalculatePriceIn returns the project total in currency (the reporting currency if empty) with the rates effective on the date.
+func (p *Project) CalculatePriceIn(currency string, on unit.Date) (float64, error) {
+	return p.Root.CalculatePriceIn(p.CurrencyConversion(currency, on))
+}
+
+// CostBreakdownIn returns the project breakdown in currency (the reporting currency if empty) with the rates effective on the date.
+func (p *Project) CostBreakdownIn(currency string, on unit.Date) (CostBreakdown, error) {
+	return p.Root.CostBreakdownIn(p.CurrencyConversion(currency, on))
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"explosio/core/material"
+	"explosio/core/unit"
+)
+
+// buildCurrencyProject returns a project with tiles bought in CHF and labor paid in EUR.
+func buildCurrencyProject() (*Project, *Activity) {
+	root, plaster, tiling := buildLinkTestTree()
+	tiling.Price.Value = 100
+	tiling.AddCountableMaterial(material.NewCountableMaterial("Tiles", "", *unit.NewPrice(20, "CHF"), 10))
+	plaster.AddHumanResource(newElectrician())
+	proj := NewProject(root)
+	proj.ExchangeRates = unit.ExchangeRates{
+		{From: "CHF", To: "EUR", Rate: 1.05, Date: unit.NewDate(2026, time.January, 1)},
+		{From: "CHF", To: "EUR", Rate: 1.10, Date: unit.NewDate(2026, time.June, 1)},
+	}
+	return proj, tiling
+}
+
+func TestCostBreakdownIn(t *testing.T) {
+	proj, tiling := buildCurrencyProject()
+	on := unit.NewDate(2026, time.March, 2)
+
+	cb, err := proj.CostBreakdownIn("", on)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(cb.Materials-210) > 1e-9 || cb.Human != 200 || cb.Activities != 100 {
+		t.Errorf("breakdown = %+v, want materials 210, human 200, activities 100 EUR", cb)
+	}
+	// The rate effective on the date is used; CHF totals convert the EUR items back.
+	if total, _ := proj.CalculatePriceIn("EUR", unit.NewDate(2026, time.June, 1)); math.Abs(total-520) > 1e-9 {
+		t.Errorf("total in June = %v, want 520 EUR", total)
+	}
+	// Converted amounts are rounded to 4 decimals: 100 EUR / 1.05 = 95.2381 CHF.
+	if total, _ := tiling.CalculatePriceIn(proj.CurrencyConversion("CHF", on)); total != 295.2381 {
+		t.Errorf("Tiling in CHF = %v, want 295.2381", total)
+	}
+	if _, err := proj.CalculatePriceIn("USD", on); err == nil || !strings.Contains(err.Error(), "no exchange rate") {
+		t.Errorf("USD without a rate: err = %v, want a missing rate error", err)
+	}
+}
+
+func TestProject_ConversionDate(t *testing.T) {
+	proj, _ := buildCurrencyProject()
+	if got := proj.ConversionDate(); got.String() != "2026-06-01" {
+		t.Errorf("without status date = %s, want the latest rate date 2026-06-01", got.String())
+	}
+	status := unit.NewDate(2026, time.March, 2)
+	proj.StatusDate = &status
+	if got := proj.ConversionDate(); got.String() != "2026-03-02" {
+		t.Errorf("with status date = %s, want 2026-03-02", got.String())
+	}
+	if got := NewProject(NewActivity("A", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(1, "EUR"))).ConversionDate(); !got.Time.IsZero() {
+		t.Errorf("without rates = %s, want the zero date", got.String())
+	}
+}
+
+func TestProject_ValidateCurrencies(t *testing.T) {
+	proj, _ := buildCurrencyProject()
+	for _, w := range proj.Validate().Warnings {
+		if strings.Contains(w.Message, "currenc") || strings.Contains(w.Message, "exchange rate") {
+			t.Errorf("unexpected warning with exchange rates: %v", w)
+		}
+	}
+	proj.Currency = "USD"
+	if r := proj.Validate(); len(r.Warnings) < 2 {
+		t.Errorf("warnings = %v, want missing CHF and EUR rates to USD", r.Warnings)
+	}
+	proj.Currency = ""
+	// Without rates, mixed currencies are reported as in Activity.Validate.
+	proj.ExchangeRates = nil
+	found := false
+	for _, w := range proj.Validate().Warnings {
+		found = found || strings.Contains(w.Message, "multiple currencies used: CHF, EUR")
+	}
+	if !found {
+		t.Error("expected a mixed currencies warning without exchange rates")
+	}
+}
+
+func TestProject_ExchangeRatesRoundTrip(t *testing.T) {
+	proj, _ := buildCurrencyProject()
+	proj.Currency = "CHF"
+	var buf bytes.Buffer
+	if err := proj.WriteYAML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ReadYAML(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.ReportingCurrency() != "CHF" || len(loaded.ExchangeRates) != 2 || loaded.ExchangeRates[1].Rate != 1.10 {
+		t.Errorf("loaded currency %q, rates %+v", loaded.ReportingCurrency(), loaded.ExchangeRates)
+	}
+	if got := loaded.Currencies(); len(got) != 2 || got[0] != "CHF" || got[1] != "EUR" {
+		t.Errorf("currencies = %v, want [CHF EUR]", got)
+	}
+}
@@ -0,0 +1,75 @@
+package core
+
+import "explosio/core/unit"
+
+// DependencyType is the kind of link between a predecessor and the dependent activity.
+type DependencyType string
+
+const (
+	FinishToStart  DependencyType = "FS" // Successor starts after the predecessor finishes (default)
+	StartToStart   DependencyType = "SS" // Successor starts after the predecessor starts
+	FinishToFinish DependencyType = "FF" // Successor finishes after the predecessor finishes
+	StartToFinish  DependencyType = "SF" // Successor finishes after the predecessor starts
+)
+
+// Dependency is an explicit link to a predecessor activity.
+// Lag delays the successor by the given duration; a negative lag is a lead (overlap).
+type Dependency struct {
+	Activity *Activity
+	Type     DependencyType
+	Lag      unit.Duration
+}
+
+// kind returns the dependency type, defaulting to finish-to-start when empty.
+func (d *Dependency) kind() DependencyType {
+	if d.Type == "" {
+		return FinishToStart
+	}
+	return d.Type
+}
+
+// IsValidDependencyType returns true if t is one of FS, SS, FF, SF (or empty, meaning FS).
+func IsValidDependencyType(t DependencyType) bool {
+	switch t {
+	case "", FinishToStart, StartToStart, FinishToFinish, StartToFinish:
+		return true
+	}
+	return false
+}
+
+// cpmLink is a precedence edge of the CPM graph with its lag in hours.
+type cpmLink struct {
+	pred *Activity
+	typ  DependencyType
+	lag  float64
+}
+
+// earliestStart returns the earliest start of the successor (of duration dur hours) allowed by the link,
+// given the predecessor's start and finish.
+func (l cpmLink) earliestStart(predStart, predFinish, dur float64) float64 {
+	switch l.typ {
+	case StartToStart:
+		return predStart + l.lag
+	case FinishToFinish:
+		return predFinish + l.lag - dur
+	case StartToFinish:
+		return predStart + l.lag - dur
+	default:
+		return predFinish + l.lag
+	}
+}
+
+// latestFinish returns the latest finish of the predecessor (of duration dur hours) allowed by the link,
+// given the successor's start and finish.
+func (l cpmLink) latestFinish(succStart, succFinish, dur float64) float64 {
+	switch l.typ {
+	case StartToStart:
+		return succStart - l.lag + dur
+	case FinishToFinish:
+		return succFinish - l.lag
+	case StartToFinish:
+		return succFinish - l.lag + dur
+	default:
+		return succStart - l.lag
+	}
+}
@@ -0,0 +1,300 @@
+package core
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"explosio/core/unit"
+)
+
+// EarnedValue holds the earned-value measures of an activity or subtree as of a status date.
+// BAC is the budget at completion (CalculatePrice), PV the budget of the work planned by the status date,
+// EV the budget of the work performed (percent complete), AC the actual cost.
+type EarnedValue struct {
+	BAC float64
+	PV  float64
+	EV  float64
+	AC  float64
+}
+
+// SV returns the schedule variance EV - PV (negative = behind schedule).
+func (e EarnedValue) SV() float64 { return e.EV - e.PV }
+
+// CV returns the cost variance EV - AC (negative = over budget).
+func (e EarnedValue) CV() float64 { return e.EV - e.AC }
+
+// SPI returns the schedule performance index EV / PV (0 if nothing is planned yet).
+func (e EarnedValue) SPI() float64 {
+	if e.PV == 0 {
+		return 0
+	}
+	return e.EV / e.PV
+}
+
+// CPI returns the cost performance index EV / AC (0 if there is no actual cost yet).
+func (e EarnedValue) CPI() float64 {
+	if e.AC == 0 {
+		return 0
+	}
+	return e.EV / e.AC
+}
+
+// EAC returns the estimate at completion, assuming the current cost performance continues: BAC / CPI.
+// Without actual costs or earned value the budget is kept: AC + (BAC - EV).
+func (e EarnedValue) EAC() float64 {
+	if cpi := e.CPI(); cpi > 0 {
+		return e.BAC / cpi
+	}
+	return e.AC + e.BAC - e.EV
+}
+
+// ETC returns the estimate to complete EAC - AC.
+func (e EarnedValue) ETC() float64 { return e.EAC() - e.AC }
+
+// VAC returns the variance at completion BAC - EAC (negative = expected overrun).
+func (e EarnedValue) VAC() float64 { return e.BAC - e.EAC() }
+
+// add sums the measures of o into e.
+func (e *EarnedValue) add(o EarnedValue) {
+	e.BAC += o.BAC
+	e.PV += o.PV
+	e.EV += o.EV
+	e.AC += o.AC
+}
+
+// ActivityEarnedValue is the earned value of an activity rolled up through its subtree.
+type ActivityEarnedValue struct {
+	Activity *Activity
+	Depth    int // Depth in the tree (root = 0)
+	EarnedValue
+}
+
+// EVMReport is the earned-value analysis of a project as of a status date.
+type EVMReport struct {
+	StatusDate unit.Date
+	Currency   string
+	Project    EarnedValue
+	Activities []ActivityEarnedValue // In tree order
+}
+
+// EVMPoint is a point of the S-curve: cumulative PV, EV and AC at the end of a period.
+// EV and AC are known only up to the status date (Actual is false after it).
+type EVMPoint struct {
+	Date   unit.Date // Last day of the period
+	PV     float64
+	EV     float64
+	AC     float64
+	Actual bool
+}
+
+// evmWindows holds, per activity, the planned window (from the schedule without progress) and the actual
+// window (actual start, or planned start, to actual finish or the status date) in CPM hours, with the budget
+// of the activity's own scope (price, materials and resources, without sub-activities) and its actual cost,
+// both in the report currency.
+type evmWindows struct {
+	planned map[*Activity]SlackInfo
+	budget  map[*Activity]float64
+	actual  map[*Activity]float64
+	status  float64
+	opts    scheduleOptions
+}
+
+// newEVMWindows computes the planned schedule from projectStart; status is the status date. Budgets and actual
+// costs are converted with c (actual costs are in the currency of the activity price), or added as they are if
+// c is nil; it fails if a currency has no exchange rate on the conversion date.
+func (a *Activity) newEVMWindows(projectStart, status unit.Date, opts scheduleOptions, c *CurrencyConversion) (evmWindows, error) {
+	opts.start = &projectStart
+	opts.status = nil // Planned values follow the plan, not the progress
+	planned, _ := a.calculateSlack(opts)
+	w := evmWindows{planned: planned, budget: make(map[*Activity]float64), actual: make(map[*Activity]float64),
+		status: opts.hoursAtEndOf(status), opts: opts}
+	for _, act := range a.GetActivities() {
+		mb, err := act.ownMoneyBreakdownIn(c)
+		if err != nil {
+			return evmWindows{}, err
+		}
+		total := mb.Total()
+		w.budget[act] = total.Float64()
+		if w.actual[act], err = c.convertFloat(act.ActualCost, act.Price.Currency); err != nil {
+			return evmWindows{}, fmt.Errorf("%s: %w", act.Name, err)
+		}
+	}
+	return w, nil
+}
+
+// share returns the part of a window [from, to) elapsed at t (0 to 1); a zero-length window is a step at from.
+func share(from, to, t float64) float64 {
+	switch {
+	case t >= to && t >= from:
+		return 1
+	case t <= from:
+		return 0
+	}
+	return (t - from) / (to - from)
+}
+
+// at returns the own PV, EV and AC of the activity at time t (hours). EV and AC are spread linearly over
+// the actual window, so that they reach the reported values at the status date.
+func (w evmWindows) at(a *Activity, t float64) EarnedValue {
+	info := w.planned[a]
+	budget := w.budget[a]
+	e := EarnedValue{BAC: budget, PV: budget * share(info.ES, info.EF, t)}
+	if !a.IsStarted() && w.actual[a] == 0 {
+		return e
+	}
+	from, to := info.ES, w.status
+	if a.ActualStart != nil {
+		from = w.opts.hoursAt(*a.ActualStart)
+	}
+	if a.ActualFinish != nil {
+		to = w.opts.hoursAtEndOf(*a.ActualFinish)
+	}
+	if from > to {
+		from = to // Work reported before its planned start: count it at the status date
+	}
+	if t > w.status {
+		t = w.status
+	}
+	s := share(from, to, t)
+	e.EV = budget * a.completedFraction() * s
+	e.AC = w.actual[a] * s
+	return e
+}
+
+// EarnedValue computes PV, EV and AC for every activity as of the end of the status date, with the planned
+// schedule starting at projectStart (calendar time) and costs added without currency conversion;
+// see Project.EarnedValue for the project settings.
+func (a *Activity) EarnedValue(projectStart, status unit.Date) *EVMReport {
+	r, _ := a.earnedValue(projectStart, status, defaultScheduleOptions(), nil)
+	return r
+}
+
+func (a *Activity) earnedValue(projectStart, status unit.Date, opts scheduleOptions, c *CurrencyConversion) (*EVMReport, error) {
+	w, err := a.newEVMWindows(projectStart, status, opts, c)
+	if err != nil {
+		return nil, err
+	}
+	r := &EVMReport{StatusDate: status, Currency: c.reportCurrency(a.Price.Currency)}
+	var walk func(act *Activity, depth int) EarnedValue
+	walk = func(act *Activity, depth int) EarnedValue {
+		i := len(r.Activities)
+		r.Activities = append(r.Activities, ActivityEarnedValue{Activity: act, Depth: depth})
+		total := w.at(act, w.status)
+		for _, child := range act.Activities {
+			total.add(walk(child, depth+1))
+		}
+		r.Activities[i].EarnedValue = total
+		return total
+	}
+	r.Project = walk(a, 0)
+	return r, nil
+}
+
+// SCurve returns the cumulative PV, EV and AC at the end of each period, from the period containing
+// projectStart to the one containing the later of the planned finish and the status date.
+func (a *Activity) SCurve(projectStart, status unit.Date, period LoadPeriod) []EVMPoint {
+	points, _ := a.sCurve(projectStart, status, period, defaultScheduleOptions(), nil)
+	return points
+}
+
+func (a *Activity) sCurve(projectStart, status unit.Date, period LoadPeriod, opts scheduleOptions, c *CurrencyConversion) ([]EVMPoint, error) {
+	w, err := a.newEVMWindows(projectStart, status, opts, c)
+	if err != nil {
+		return nil, err
+	}
+	end := w.status
+	for _, info := range w.planned {
+		if info.EF > end {
+			end = info.EF
+		}
+	}
+	activities := a.GetActivities()
+	var points []EVMPoint
+	for d := periodStart(projectStart, period); ; d = nextPeriod(d, period) {
+		next := nextPeriod(d, period)
+		t := w.opts.hoursAt(next)
+		p := EVMPoint{Date: next.AddDays(-1), Actual: t <= w.status+slackEpsilon}
+		for _, act := range activities {
+			e := w.at(act, t)
+			p.PV += e.PV
+			p.EV += e.EV
+			p.AC += e.AC
+		}
+		points = append(points, p)
+		if t >= end-slackEpsilon || len(points) > maxCurvePoints {
+			break
+		}
+	}
+	return points, nil
+}
+
+// maxCurvePoints bounds the S-curve, so a calendar without working time cannot loop forever.
+const maxCurvePoints = 10000
+
+// EarnedValue computes the earned-value analysis as of the status date using the project settings. Budgets and
+// actual costs are in the reporting currency, converted with the exchange rates effective on the conversion date;
+// it fails if a currency has no exchange rate on that date.
+func (p *Project) EarnedValue(projectStart, status unit.Date) (*EVMReport, error) {
+	c := p.CurrencyConversion("", p.ConversionDate())
+	return p.Root.earnedValue(projectStart, status, p.scheduleOptions(), &c)
+}
+
+// SCurve returns the cumulative PV, EV and AC per period using the project settings, in the reporting currency
+// as EarnedValue.
+func (p *Project) SCurve(projectStart, status unit.Date, period LoadPeriod) ([]EVMPoint, error) {
+	c := p.CurrencyConversion("", p.ConversionDate())
+	return p.Root.sCurve(projectStart, status, period, p.scheduleOptions(), &c)
+}
+
+// formatIndex formats the performance index ev / base, "-" if it is undefined (base is 0).
+func formatIndex(ev, base float64) string {
+	if base == 0 {
+		return "-"
+	}
+	return strconv.FormatFloat(ev/base, 'f', 2, 64)
+}
+
+// PrintEVM prints the earned-value table per activity (indented by depth) and the project indicators.
+func PrintEVM(r *EVMReport) {
+	fmt.Println("--------------------------------")
+	fmt.Printf("   Earned Value as of %s (%s)\n", r.StatusDate.String(), r.Currency)
+	fmt.Println("--------------------------------")
+	fmt.Printf("%-28s %11s %11s %11s %11s %11s %11s %5s %5s\n", "Activity", "BAC", "PV", "EV", "AC", "SV", "CV", "SPI", "CPI")
+	fmt.Println(strings.Repeat("-", 28+12*6+6*2))
+	for _, v := range r.Activities {
+		name := strings.Repeat("  ", v.Depth) + v.Activity.Name
+		if len(name) > 28 {
+			name = name[:25] + "..."
+		}
+		fmt.Printf("%-28s %11.2f %11.2f %11.2f %11.2f %+11.2f %+11.2f %5s %5s\n", name,
+			v.BAC, v.PV, v.EV, v.AC, v.SV(), v.CV(), formatIndex(v.EV, v.PV), formatIndex(v.EV, v.AC))
+	}
+	e := r.Project
+	fmt.Println("--------------------------------")
+	fmt.Printf("BAC: %.2f  PV: %.2f  EV: %.2f  AC: %.2f\n", e.BAC, e.PV, e.EV, e.AC)
+	fmt.Printf("SV: %+.2f  CV: %+.2f  SPI: %s  CPI: %s\n", e.SV(), e.CV(), formatIndex(e.EV, e.PV), formatIndex(e.EV, e.AC))
+	fmt.Printf("EAC: %.2f  ETC: %.2f  VAC: %+.2f\n", e.EAC(), e.ETC(), e.VAC())
+}
+
+// WriteSCurveCSV writes the S-curve as CSV: date, pv, ev, ac. EV and AC are empty after the status date.
+func WriteSCurveCSV(out io.Writer, points []EVMPoint) error {
+	cw := csv.NewWriter(out)
+	if err := cw.Write([]string{"date", "pv", "ev", "ac"}); err != nil {
+		return err
+	}
+	for _, p := range points {
+		row := []string{p.Date.String(), strconv.FormatFloat(p.PV, 'f', 2, 64), "", ""}
+		if p.Actual {
+			row[2] = strconv.FormatFloat(p.EV, 'f', 2, 64)
+			row[3] = strconv.FormatFloat(p.AC, 'f', 2, 64)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"explosio/core/unit"
+)
+
+func TestEarnedValue_Indicators(t *testing.T) {
+	// Plaster (4d, 400 EUR) then Tiling (3d, 300 EUR); at the end of day 2 Plaster is 25% done and cost 150.
+	proj, plaster, tiling := newProgressProject()
+	plaster.Price.Value = 400
+	tiling.Price.Value = 300
+	plaster.SetProgress(25, date(2), nil)
+	plaster.ActualCost = 150
+
+	r, err := proj.EarnedValue(*proj.Start, *date(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := r.Project
+	if e.BAC != 700 || e.PV != 200 || e.EV != 100 || e.AC != 150 {
+		t.Fatalf("BAC/PV/EV/AC = %v/%v/%v/%v, want 700/200/100/150", e.BAC, e.PV, e.EV, e.AC)
+	}
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"SV", e.SV(), -100},
+		{"CV", e.CV(), -50},
+		{"SPI", e.SPI(), 0.5},
+		{"CPI", e.CPI(), 2.0 / 3},
+		{"EAC", e.EAC(), 1050},
+		{"ETC", e.ETC(), 900},
+		{"VAC", e.VAC(), -350},
+	}
+	for _, c := range checks {
+		if math.Abs(c.got-c.want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	// Tiling has not started: its budget counts in BAC only.
+	for _, v := range r.Activities {
+		if v.Activity == tiling && (v.BAC != 300 || v.PV != 0 || v.EV != 0 || v.EAC() != 300) {
+			t.Errorf("Tiling = %+v, want BAC 300 and nothing else", v.EarnedValue)
+		}
+	}
+}
+
+func TestSCurve_Daily(t *testing.T) {
+	proj, plaster, tiling := newProgressProject()
+	plaster.Price.Value = 400
+	tiling.Price.Value = 300
+	plaster.SetProgress(25, date(2), nil)
+	plaster.ActualCost = 150
+
+	points, err := proj.SCurve(*proj.Start, *date(3), PeriodDay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 7 || points[6].Date.String() != "2026-03-08" || points[6].PV != 700 {
+		t.Fatalf("points = %+v, want 7 days ending 2026-03-08 with PV 700", points)
+	}
+	if p := points[0]; p.PV != 100 || p.EV != 50 || p.AC != 75 || !p.Actual {
+		t.Errorf("day 1 = %+v, want PV 100, EV 50, AC 75", p)
+	}
+	if p := points[1]; p.EV != 100 || p.AC != 150 || !p.Actual || points[2].Actual {
+		t.Errorf("day 2 = %+v, want EV 100, AC 150 and no actuals after the status date", p)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSCurveCSV(&buf, points); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "date,pv,ev,ac" || lines[2] != "2026-03-03,200.00,100.00,150.00" || lines[3] != "2026-03-04,300.00,," {
+		t.Errorf("CSV = %q", lines)
+	}
+}
+
+func TestProject_EarnedValueConvertsCosts(t *testing.T) {
+	// Tiling (100 CHF, 200 CHF of tiles) is done and cost 200 CHF; Plaster (labor 200 EUR) has not started.
+	proj, tiling := buildCurrencyProject()
+	tiling.Price = *unit.NewPrice(100, "CHF")
+	tiling.SetProgress(100, date(2), date(4))
+	tiling.ActualCost = 200
+
+	r, err := proj.EarnedValue(unit.NewDate(2026, time.March, 2), *date(20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// June rate 1.10: Tiling budget 330 EUR, actual cost 220 EUR.
+	if e := r.Project; math.Abs(e.BAC-530) > 1e-9 || math.Abs(e.EV-330) > 1e-9 || math.Abs(e.AC-220) > 1e-9 || r.Currency != "EUR" {
+		t.Errorf("BAC/EV/AC = %v/%v/%v %s, want 530/330/220 EUR", e.BAC, e.EV, e.AC, r.Currency)
+	}
+	points, err := proj.SCurve(unit.NewDate(2026, time.March, 2), *date(20), PeriodMonth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last := points[len(points)-1]; math.Abs(last.PV-530) > 1e-9 || math.Abs(last.AC-220) > 1e-9 {
+		t.Errorf("last point = %+v, want PV 530, AC 220", last)
+	}
+
+	tiling.Price.Currency = "USD"
+	if _, err := proj.EarnedValue(unit.NewDate(2026, time.March, 2), *date(20)); err == nil {
+		t.Error("expected an error for a price without exchange rate")
+	}
+}
@@ -18,12 +18,27 @@ type Schedule struct {
 }
 
 // ComputeSchedule computes start/end dates for all activities given a project start date.
+// Time is continuous: weekends and holidays are not skipped (see ComputeScheduleWithCalendar).
 func (a *Activity) ComputeSchedule(projectStart unit.Date) map[*Activity]Schedule {
-	slackMap := a.CalculateSlack()
+	return a.ComputeScheduleWithCalendar(projectStart, nil)
+}
+
+// ComputeScheduleWithCalendar computes start/end dates for all activities, placing work on the working days of cal.
+// Each day of CPM time (CalendarHoursPerDay hours) is mapped to one working day of the calendar, so "1 day" of
+// work takes one working day. If cal is nil, time is continuous.
+func (a *Activity) ComputeScheduleWithCalendar(projectStart unit.Date, cal *unit.Calendar) map[*Activity]Schedule {
+	opts := defaultScheduleOptions()
+	opts.cal = cal
+	return a.computeSchedule(projectStart, opts)
+}
+
+// computeSchedule computes the dates from projectStart; constraint dates are converted relative to it.
+func (a *Activity) computeSchedule(projectStart unit.Date, opts scheduleOptions) map[*Activity]Schedule {
+	opts.start = &projectStart
+	slackMap, _ := a.calculateSlack(opts)
 	result := make(map[*Activity]Schedule)
 	for act, info := range slackMap {
-		start := projectStart.AddHours(info.ES)
-		end := projectStart.AddHours(info.EF)
+		start, end := scheduleDates(projectStart, opts.cal, opts.conv, info.ES, info.EF)
 		result[act] = Schedule{
 			Activity:  act,
 			StartDate: start,
@@ -35,11 +50,29 @@ func (a *Activity) ComputeSchedule(projectStart unit.Date) map[*Activity]Schedul
 	return result
 }
 
+// scheduleDates converts early start/finish hours into dates. With a calendar, a day of CPM time (conv.HoursPerDay)
+// is mapped to a working day of the calendar; a zero-duration activity finishes on its start date.
+func scheduleDates(projectStart unit.Date, cal *unit.Calendar, conv unit.DurationConversion, es, ef float64) (unit.Date, unit.Date) {
+	if cal == nil || conv.HoursPerDay <= 0 {
+		return projectStart.AddHours(es), projectStart.AddHours(ef)
+	}
+	toWorking := cal.HoursPerDay / conv.HoursPerDay
+	start := cal.StartAt(projectStart, es*toWorking)
+	if ef <= es {
+		return start, start
+	}
+	return start, cal.FinishAt(projectStart, ef*toWorking)
+}
+
 // GanttConfig holds options for Gantt output.
 type GanttConfig struct {
 	ProjectStart unit.Date
-	Width        int  // Character width for the bar (default 40)
-	ShowDates    bool // Show date labels (default true)
+	Width        int                      // Character width for the bar (default 40)
+	ShowDates    bool                     // Show date labels (default true)
+	Calendar     *unit.Calendar           // Working calendar for dates (nil = continuous time)
+	Conversion   *unit.DurationConversion // Duration to hours conversion (nil = calendar time)
+	Deadline     *unit.Date               // Project deadline (nil = none)
+	StatusDate   *unit.Date               // Status date for progress (nil = progress not shown)
 }
 
 // PrintGantt prints an ASCII Gantt chart for the activity tree.
@@ -47,13 +80,16 @@ func (a *Activity) PrintGantt(cfg GanttConfig) {
 	if cfg.Width <= 0 {
 		cfg.Width = 40
 	}
-	schedule := a.ComputeSchedule(cfg.ProjectStart)
-	var projectEnd float64
-	if a.hasExplicitDependencies() {
-		_, projectEnd = a.cpmForwardBackward()
-	} else {
-		_, projectEnd = a.criticalPathAndDuration()
+	opts := defaultScheduleOptions()
+	if cfg.Conversion != nil {
+		opts.conv = *cfg.Conversion
 	}
+	opts.cal = cfg.Calendar
+	opts.start = &cfg.ProjectStart
+	opts.deadline = cfg.Deadline
+	opts.status = cfg.StatusDate
+	schedule := a.computeSchedule(cfg.ProjectStart, opts)
+	_, projectEnd := a.calculateSlack(opts)
 	totalHours := projectEnd
 	if totalHours <= 0 {
 		totalHours = 1
@@ -65,6 +101,15 @@ func (a *Activity) PrintGantt(cfg GanttConfig) {
 	fmt.Println("--------------------------------")
 	if cfg.ShowDates {
 		fmt.Printf("Project start: %s | Duration: %.0f hours\n", cfg.ProjectStart.String(), totalHours)
+		if cfg.Calendar != nil {
+			fmt.Printf("Calendar: %s\n", cfg.Calendar.String())
+		}
+		if cfg.Deadline != nil {
+			fmt.Printf("Deadline: %s\n", cfg.Deadline.String())
+		}
+		if cfg.StatusDate != nil {
+			fmt.Printf("Status date: %s (▓ completed, █ remaining)\n", cfg.StatusDate.String())
+		}
 		fmt.Println("--------------------------------")
 	}
 
@@ -85,9 +130,17 @@ func (a *Activity) PrintGantt(cfg GanttConfig) {
 			endIdx = barLen
 		}
 
+		// Completed work is drawn from the start of the bar, in proportion to the percent complete.
+		doneIdx := startIdx
+		if opts.tracksProgress() {
+			doneIdx += int(act.completedFraction()*float64(endIdx-startIdx) + 0.5)
+		}
+
 		var bar strings.Builder
 		for i := 0; i < barLen; i++ {
-			if i >= startIdx && i < endIdx {
+			if i >= startIdx && i < doneIdx {
+				bar.WriteString("▓")
+			} else if i >= startIdx && i < endIdx {
 				bar.WriteString("█")
 			} else {
 				bar.WriteString("░")
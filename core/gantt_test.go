@@ -0,0 +1,80 @@
+package core
+
+import (
+	"bytes"
+	"explosio/core/unit"
+	"testing"
+	"time"
+)
+
+func TestComputeScheduleWithCalendar_SkipsWeekend(t *testing.T) {
+	// A (2d) then B (2d), starting on Thursday: A runs Thu-Fri, B runs Mon-Tue.
+	a := NewActivity("A", "", *unit.NewDuration(2, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	b := NewActivity("B", "", *unit.NewDuration(2, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	a.AddActivity(b)
+	b.AddDependsOn(a)
+
+	thursday := unit.NewDate(2026, time.December, 10)
+	sched := a.ComputeScheduleWithCalendar(thursday, unit.NewCalendar())
+
+	if got := sched[a].StartDate.String(); got != "2026-12-10" {
+		t.Errorf("A start = %s, want 2026-12-10", got)
+	}
+	if got := sched[a].EndDate.String(); got != "2026-12-11" {
+		t.Errorf("A end = %s, want 2026-12-11", got)
+	}
+	if got := sched[b].StartDate.String(); got != "2026-12-14" {
+		t.Errorf("B start = %s, want 2026-12-14", got)
+	}
+	if got := sched[b].EndDate.String(); got != "2026-12-15" {
+		t.Errorf("B end = %s, want 2026-12-15", got)
+	}
+}
+
+func TestComputeSchedule_ContinuousWithoutCalendar(t *testing.T) {
+	a := NewActivity("A", "", *unit.NewDuration(2, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	friday := unit.NewDate(2026, time.December, 11)
+	sched := a.ComputeSchedule(friday)
+	if got := sched[a].EndDate.String(); got != "2026-12-13" {
+		t.Errorf("A end = %s, want 2026-12-13 (continuous time)", got)
+	}
+}
+
+func TestProject_CalendarRoundTrip(t *testing.T) {
+	root := NewActivity("Root", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	proj := NewProject(root)
+	proj.Calendar = unit.NewCalendar().AddHoliday(unit.NewDate(2026, time.December, 25))
+
+	var buf bytes.Buffer
+	if err := proj.WriteYAML(&buf); err != nil {
+		t.Fatalf("WriteYAML: %v", err)
+	}
+	read, err := ReadYAML(&buf)
+	if err != nil {
+		t.Fatalf("ReadYAML: %v", err)
+	}
+	if read.Calendar == nil || len(read.Calendar.Holidays) != 1 || read.Calendar.Holidays[0].String() != "2026-12-25" {
+		t.Fatalf("calendar after round-trip = %+v", read.Calendar)
+	}
+	if read.Calendar.WorkingHoursOn(unit.NewDate(2026, time.December, 25)) != 0 {
+		t.Error("holiday should be non-working after round-trip")
+	}
+}
+
+func TestProject_ComputeSchedule_WorkingTimeWithCalendar(t *testing.T) {
+	// With working time (8h days) and an 8h calendar, "2 days" still takes two working days.
+	a := NewActivity("A", "", *unit.NewDuration(2, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	proj := NewProject(a)
+	working := unit.WorkingTimeConversion(8, 5)
+	proj.Conversion = &working
+	proj.Calendar = unit.NewCalendar()
+
+	friday := unit.NewDate(2026, time.December, 11)
+	sched := proj.ComputeSchedule(friday)
+	if got := sched[a].EF; got != 16 {
+		t.Errorf("A EF = %v, want 16 working hours", got)
+	}
+	if got := sched[a].EndDate.String(); got != "2026-12-14" {
+		t.Errorf("A end = %s, want 2026-12-14", got)
+	}
+}
@@ -0,0 +1,265 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+
+	"explosio/core/unit"
+)
+
+// LevelingPriority is the rule used by resource leveling to choose which eligible activity is scheduled first.
+type LevelingPriority string
+
+const (
+	PriorityLeastFloat      LevelingPriority = "least-float"      // Lowest total float first (default): critical work keeps its dates
+	PriorityLongestDuration LevelingPriority = "longest-duration" // Longest activity first
+	PriorityEarliestStart   LevelingPriority = "earliest-start"   // Earliest unleveled start first
+)
+
+// IsValidLevelingPriority returns true if p is a known priority rule (or empty, meaning least float).
+func IsValidLevelingPriority(p LevelingPriority) bool {
+	switch p {
+	case "", PriorityLeastFloat, PriorityLongestDuration, PriorityEarliestStart:
+		return true
+	}
+	return false
+}
+
+// LevelingMove is an activity whose start changed with leveling (hours from project start).
+type LevelingMove struct {
+	Activity *Activity
+	OldStart float64
+	NewStart float64
+}
+
+// LevelingResult is the outcome of resource leveling.
+type LevelingResult struct {
+	Moves  []LevelingMove // Activities that moved, in tree order
+	OldEnd float64        // Project end before leveling (hours)
+	NewEnd float64        // Project end after leveling (hours)
+}
+
+// hasLevelingDelays returns true if any activity in the tree has a leveling delay.
+func (a *Activity) hasLevelingDelays() bool {
+	if a.LevelingDelay != nil {
+		return true
+	}
+	for _, child := range a.Activities {
+		if child.hasLevelingDelays() {
+			return true
+		}
+	}
+	return false
+}
+
+// ClearLeveling removes the leveling delays of the activity and all descendants.
+func (a *Activity) ClearLeveling() {
+	for _, act := range a.GetActivities() {
+		act.LevelingDelay = nil
+	}
+}
+
+// Level resolves resource over-allocations with calendar-time conversion; see Project.Level for the project settings.
+func (a *Activity) Level(priority LevelingPriority) *LevelingResult {
+	return a.level(defaultScheduleOptions(), priority)
+}
+
+// level runs a serial schedule-generation scheme: activities are scheduled one at a time, in precedence order,
+// choosing among the eligible ones by priority, each at the first time from its precedence-feasible start at which
+// all its resources are available. The resulting shifts are stored as leveling delays, so the CPM reproduces the
+// leveled schedule. Activities with float absorb the delays first; the project end moves only if needed.
+// Start times follow the CPM forward pass (constraints, ALAP, progress): work already started keeps its dates
+// and books its resources, work not started is never placed before the status date.
+func (a *Activity) level(opts scheduleOptions, priority LevelingPriority) *LevelingResult {
+	a.ClearLeveling()
+	base, oldEnd := a.calculateSlack(opts)
+
+	all := make(map[*Activity]bool)
+	preds := make(map[*Activity][]cpmLink)
+	a.buildCPMGraph(nil, all, preds, opts)
+	order := topoOrder(all, preds)
+	index := make(map[*Activity]int)
+	for i, act := range a.GetActivities() {
+		index[act] = i
+	}
+
+	// Count the distinct predecessors still to schedule.
+	waiting := make(map[*Activity]int)
+	succs := make(map[*Activity][]*Activity)
+	for _, act := range order {
+		seen := make(map[*Activity]bool)
+		for _, l := range preds[act] {
+			if all[l.pred] && !seen[l.pred] {
+				seen[l.pred] = true
+				waiting[act]++
+				succs[l.pred] = append(succs[l.pred], act)
+			}
+		}
+	}
+	var eligible []*Activity
+	for _, act := range order {
+		if waiting[act] == 0 {
+			eligible = append(eligible, act)
+		}
+	}
+
+	// ALAP activities start no earlier than their late start, as in the CPM.
+	floor := make(map[*Activity]float64)
+	for act, info := range base {
+		if act.Constraint != nil && act.Constraint.Type == AsLateAsPossible {
+			floor[act] = info.LS
+		}
+	}
+
+	before := func(x, y *Activity) bool {
+		// Work already started holds its resources: it is placed before any activity it could conflict with.
+		if opts.tracksProgress() && x.IsStarted() != y.IsStarted() {
+			return x.IsStarted()
+		}
+		switch priority {
+		case PriorityLongestDuration:
+			if hx, hy := opts.activityHours(x), opts.activityHours(y); hx != hy {
+				return hx > hy
+			}
+		case PriorityEarliestStart:
+		default:
+			if base[x].TotalFloat != base[y].TotalFloat {
+				return base[x].TotalFloat < base[y].TotalFloat
+			}
+		}
+		if base[x].ES != base[y].ES {
+			return base[x].ES < base[y].ES
+		}
+		return index[x] < index[y]
+	}
+
+	scheduled := make(map[*Activity]SlackInfo)
+	bookings := make(map[string][]ResourceBooking)
+	for len(eligible) > 0 {
+		sort.SliceStable(eligible, func(i, j int) bool { return before(eligible[i], eligible[j]) })
+		act := eligible[0]
+		eligible = eligible[1:]
+
+		hours := opts.activityHours(act)
+		es := 0.0
+		for _, l := range preds[act] {
+			if info, ok := scheduled[l.pred]; ok {
+				if s := l.earliestStart(info.ES, info.EF, hours); s > es {
+					es = s
+				}
+			}
+		}
+		// The delay is added to the constrained start, before the ALAP floor and the status date apply.
+		constrained := opts.applyEarlyConstraint(act, es, hours)
+		natural, finish := opts.earlyDates(act, es, hours, floor)
+
+		demands := act.resourceDemands()
+		start := natural
+		if hours > 0 && len(demands) > 0 && !(opts.tracksProgress() && act.IsStarted()) {
+			start = firstAvailable(natural, hours, demands, bookings)
+			finish = start + hours
+		}
+		if finish > start {
+			for _, d := range demands {
+				bookings[d.key] = append(bookings[d.key], ResourceBooking{Activity: act, From: start, To: finish, Units: d.units})
+			}
+		}
+		if start-natural > slackEpsilon {
+			act.LevelingDelay = unit.NewDuration(start-constrained, unit.DurationUnitHour)
+		}
+		scheduled[act] = SlackInfo{ES: start, EF: finish}
+
+		for _, s := range succs[act] {
+			waiting[s]--
+			if waiting[s] == 0 {
+				eligible = append(eligible, s)
+			}
+		}
+	}
+
+	m, newEnd := a.calculateSlack(opts)
+	r := &LevelingResult{OldEnd: oldEnd, NewEnd: newEnd}
+	for _, act := range a.GetActivities() {
+		if m[act].ES-base[act].ES > slackEpsilon || base[act].ES-m[act].ES > slackEpsilon {
+			r.Moves = append(r.Moves, LevelingMove{Activity: act, OldStart: base[act].ES, NewStart: m[act].ES})
+		}
+	}
+	return r
+}
+
+// firstAvailable returns the first time from t at which every demanded resource has enough free units for
+// the whole window [t, t + hours). Candidate times are t and the ends of the existing bookings.
+// A demand larger than the capacity is checked against the demand itself (it cannot be resolved by waiting).
+func firstAvailable(t, hours float64, demands []resourceDemand, bookings map[string][]ResourceBooking) float64 {
+	candidates := []float64{t}
+	for _, d := range demands {
+		for _, b := range bookings[d.key] {
+			if b.To > t {
+				candidates = append(candidates, b.To)
+			}
+		}
+	}
+	sort.Float64s(candidates)
+	for _, c := range candidates {
+		if fits(c, c+hours, demands, bookings) {
+			return c
+		}
+	}
+	return candidates[len(candidates)-1]
+}
+
+// fits returns true if the demands can be added to the bookings over [from, to).
+func fits(from, to float64, demands []resourceDemand, bookings map[string][]ResourceBooking) bool {
+	for _, d := range demands {
+		capacity := d.capacity
+		if d.units > capacity {
+			capacity = d.units
+		}
+		// The load is piecewise constant: check it at from and where bookings start inside the window.
+		points := []float64{from}
+		for _, b := range bookings[d.key] {
+			if b.From > from && b.From < to {
+				points = append(points, b.From)
+			}
+		}
+		for _, p := range points {
+			load := d.units
+			for _, b := range bookings[d.key] {
+				if b.From <= p+slackEpsilon && p < b.To-slackEpsilon {
+					load += b.Units
+				}
+			}
+			if load > capacity+slackEpsilon {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Level resolves resource over-allocations with the project settings and stores the leveling delays
+// on the activities (see Activity.LevelingDelay). Existing delays are recomputed.
+func (p *Project) Level(priority LevelingPriority) *LevelingResult {
+	return p.Root.level(p.scheduleOptions(), priority)
+}
+
+// PrintLevelingReport prints the activities moved by leveling and the project end before and after.
+func PrintLevelingReport(r *LevelingResult) {
+	fmt.Println("--------------------------------")
+	fmt.Println("   Resource Leveling")
+	fmt.Println("--------------------------------")
+	if len(r.Moves) == 0 {
+		fmt.Println("No activity moved: no resource is over-allocated.")
+	} else {
+		fmt.Printf("%-24s %12s %12s %12s\n", "Activity", "Old start", "New start", "Delay")
+		for _, mv := range r.Moves {
+			name := mv.Activity.Name
+			if len(name) > 24 {
+				name = name[:21] + "..."
+			}
+			fmt.Printf("%-24s %11.0fh %11.0fh %+11.0fh\n", name, mv.OldStart, mv.NewStart, mv.NewStart-mv.OldStart)
+		}
+	}
+	fmt.Println("--------------------------------")
+	fmt.Printf("Project end: %.0fh -> %.0fh (%+.0fh)\n", r.OldEnd, r.NewEnd, r.NewEnd-r.OldEnd)
+}
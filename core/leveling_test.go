@@ -0,0 +1,111 @@
+package core
+
+import (
+	"bytes"
+	"testing"
+
+	"explosio/core/unit"
+)
+
+func TestLevel_WithinFloat(t *testing.T) {
+	// Tiling (3d) and Paint (1d) share the electrician; both fit in the float left by Plaster (4d).
+	root, _, tiling := buildLinkTestTree()
+	paint := NewActivity("Paint", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	root.AddActivity(paint)
+	tiling.AddHumanResource(newElectrician())
+	paint.AddHumanResource(newElectrician())
+
+	r := root.Level(PriorityLeastFloat)
+	if r.OldEnd != 96 || r.NewEnd != 96 {
+		t.Errorf("project end = %v -> %v, want 96 -> 96", r.OldEnd, r.NewEnd)
+	}
+	if len(r.Moves) != 1 || r.Moves[0].Activity != paint || r.Moves[0].NewStart != 72 {
+		t.Fatalf("moves = %+v, want Paint to 72h", r.Moves)
+	}
+	if tiling.LevelingDelay != nil || paint.LevelingDelay == nil || paint.LevelingDelay.Value != 72 {
+		t.Errorf("delays = %v / %v, want Tiling none and Paint 72h", tiling.LevelingDelay, paint.LevelingDelay)
+	}
+	if over := root.OverAllocations(); len(over) != 0 {
+		t.Errorf("over-allocations after leveling = %v, want none", over)
+	}
+}
+
+func TestLevel_ExtendsProject(t *testing.T) {
+	root, plaster, tiling := buildLinkTestTree()
+	plaster.AddHumanResource(newElectrician())
+	tiling.AddHumanResource(newElectrician())
+
+	// Least float keeps the critical Plaster first; longest duration picks it too.
+	for _, p := range []LevelingPriority{PriorityLeastFloat, PriorityLongestDuration} {
+		r := root.Level(p)
+		if r.NewEnd != 168 || len(r.Moves) != 1 || r.Moves[0].Activity != tiling {
+			t.Fatalf("%s: end %v, moves %+v, want 168 with Tiling moved", p, r.NewEnd, r.Moves)
+		}
+		m := root.CalculateSlack()
+		if m[plaster].ES != 0 || m[tiling].ES != 96 {
+			t.Errorf("%s: ES Plaster %v, Tiling %v, want 0 and 96", p, m[plaster].ES, m[tiling].ES)
+		}
+	}
+
+	// Leveling again starts from the unleveled schedule, and clearing restores it.
+	if r := root.Level(PriorityLeastFloat); r.OldEnd != 96 {
+		t.Errorf("re-leveling old end = %v, want 96", r.OldEnd)
+	}
+	root.ClearLeveling()
+	if m := root.CalculateSlack(); m[tiling].ES != 0 {
+		t.Errorf("cleared Tiling ES = %v, want 0", m[tiling].ES)
+	}
+}
+
+func TestLevel_StatusDate(t *testing.T) {
+	// Plaster is half done on the status date (end of March 2) and keeps the electrician until 72h;
+	// Tiling has not started, so it cannot be placed before the status date nor over Plaster's remaining work.
+	root, plaster, tiling := buildLinkTestTree()
+	plaster.AddHumanResource(newElectrician())
+	tiling.AddHumanResource(newElectrician())
+	proj := NewProject(root)
+	proj.Start, proj.StatusDate = date(2), date(2)
+	plaster.SetProgress(50, date(2), nil)
+
+	r := proj.Level(PriorityLeastFloat)
+	if r.OldEnd != 96 || r.NewEnd != 144 {
+		t.Errorf("project end = %v -> %v, want 96 -> 144", r.OldEnd, r.NewEnd)
+	}
+	if plaster.LevelingDelay != nil {
+		t.Errorf("Plaster delay = %v, want none for started work", plaster.LevelingDelay)
+	}
+	m := proj.CalculateSlack()
+	if m[plaster].ES != 0 || m[plaster].EF != 72 || m[tiling].ES != 72 {
+		t.Errorf("Plaster %v-%v, Tiling ES %v, want 0-72 and 72", m[plaster].ES, m[plaster].EF, m[tiling].ES)
+	}
+	if over := proj.OverAllocations(); len(over) != 0 {
+		t.Errorf("over-allocations after leveling = %v, want none", over)
+	}
+}
+
+func TestLevel_RoundTrip(t *testing.T) {
+	root, plaster, tiling := buildLinkTestTree()
+	plaster.AddHumanResource(newElectrician())
+	tiling.AddHumanResource(newElectrician())
+	proj := NewProject(root)
+	proj.Level(PriorityLeastFloat)
+
+	var buf bytes.Buffer
+	if err := proj.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ReadJSON(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, act := range loaded.Root.GetActivities() {
+		if act.Name == "Tiling" {
+			if act.LevelingDelay == nil || act.LevelingDelay.Value != 96 {
+				t.Errorf("loaded delay = %v, want 96h", act.LevelingDelay)
+			}
+			if es := loaded.CalculateSlack()[act].ES; es != 96 {
+				t.Errorf("loaded Tiling ES = %v, want 96", es)
+			}
+		}
+	}
+}
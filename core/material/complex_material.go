@@ -6,10 +6,14 @@ import "explosio/core/unit"
 // ComplexMaterial is a material made of multiple units of a measurable material (e.g. 5 pipes of 1 meter).
 type ComplexMaterial struct {
 	Name               string
+	Code               string `json:",omitempty" yaml:",omitempty"`
 	Description        string
+	Supplier           string `json:",omitempty" yaml:",omitempty"`
 	Price              unit.Price
 	UnitQuantity       int
-	MeasurableMaterial *MeasurableMaterial
+	MeasurableMaterial *MeasurableMaterial // Content of one unit, priced net (its waste and pack size are not used)
+	WastePercent       float64             `json:",omitempty" yaml:",omitempty"` // Extra units (e.g. pipes cut to length)
+	PackSize           int                 `json:",omitempty" yaml:",omitempty"` // Units per pack; 0 = sold by the unit
 }
 
 // NewComplexMaterial creates a complex material (e.g. N units of a measurable material).
@@ -17,21 +21,42 @@ func NewComplexMaterial(name string, description string, price unit.Price, unitQ
 	return &ComplexMaterial{Name: name, Description: description, Price: price, UnitQuantity: unitQuantity, MeasurableMaterial: measurableMaterial}
 }
 
-// CalculatePrice returns the complex price plus the measurable material price multiplied by unit quantity.
+// CalculatePrice returns the complex price plus the net price of the measurable material multiplied by the purchased
+// units. Waste and packs apply once, to the units: those of the measurable material are ignored.
 // If MeasurableMaterial is nil, returns only the complex Price.Value.
 func (c *ComplexMaterial) CalculatePrice() float64 {
-	price := c.Price.Value
+	return c.CalculateMoney().Float64()
+}
+
+// CalculateMoney returns the exact price (see CalculatePrice). The amount is in the complex price currency.
+func (c *ComplexMaterial) CalculateMoney() unit.Money {
+	price := c.Price.Money()
 	if c.MeasurableMaterial != nil {
-		price += float64(c.UnitQuantity) * c.MeasurableMaterial.CalculatePrice()
+		price = price.Add(c.MeasurableMaterial.NetMoney().MulInt(int64(c.PurchaseQuantity())))
 	}
 	return price
 }
 
+// PurchaseQuantity returns the units to buy: UnitQuantity plus waste, rounded up to whole units and packs.
+func (c *ComplexMaterial) PurchaseQuantity() int {
+	return int(purchaseQuantity(float64(c.UnitQuantity), c.WastePercent, float64(c.PackSize), true))
+}
+
+// CheckPurchase returns an error if the waste percentage or the pack size is negative.
+func (c *ComplexMaterial) CheckPurchase() error {
+	return checkPurchase(c.WastePercent, float64(c.PackSize))
+}
+
 // Clone returns a deep copy of the complex material.
 func (c *ComplexMaterial) Clone() *ComplexMaterial {
 	var meas *MeasurableMaterial
 	if c.MeasurableMaterial != nil {
 		meas = c.MeasurableMaterial.Clone()
 	}
-	return NewComplexMaterial(c.Name, c.Description, c.Price, c.UnitQuantity, meas)
+	clone := NewComplexMaterial(c.Name, c.Description, c.Price, c.UnitQuantity, meas)
+	clone.Code = c.Code
+	clone.Supplier = c.Supplier
+	clone.WastePercent = c.WastePercent
+	clone.PackSize = c.PackSize
+	return clone
 }
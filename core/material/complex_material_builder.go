@@ -3,6 +3,7 @@ package material
 import (
 	"errors"
 	"explosio/core/unit"
+	"fmt"
 )
 
 // ComplexMaterialBuilder builds a complex material.
@@ -35,6 +36,30 @@ func (b *ComplexMaterialBuilder) WithDescription(description string) *ComplexMat
 	return b
 }
 
+// WithCode sets the catalog code and returns the builder for chaining.
+func (b *ComplexMaterialBuilder) WithCode(code string) *ComplexMaterialBuilder {
+	b.complexMaterial.Code = code
+	return b
+}
+
+// WithWaste sets the waste percentage added to the purchased quantity and returns the builder for chaining.
+func (b *ComplexMaterialBuilder) WithWaste(percent float64) *ComplexMaterialBuilder {
+	b.complexMaterial.WastePercent = percent
+	return b
+}
+
+// WithPackSize sets the pack size (units per pack) and returns the builder for chaining.
+func (b *ComplexMaterialBuilder) WithPackSize(size int) *ComplexMaterialBuilder {
+	b.complexMaterial.PackSize = size
+	return b
+}
+
+// WithSupplier sets the supplier (used by pricing discount rules) and returns the builder for chaining.
+func (b *ComplexMaterialBuilder) WithSupplier(supplier string) *ComplexMaterialBuilder {
+	b.complexMaterial.Supplier = supplier
+	return b
+}
+
 // WithPrice sets the price and returns the builder for chaining.
 func (b *ComplexMaterialBuilder) WithPrice(price unit.Price) *ComplexMaterialBuilder {
 	b.complexMaterial.Price = price
@@ -53,7 +78,8 @@ func (b *ComplexMaterialBuilder) WithMeasurableMaterial(m *MeasurableMaterial) *
 	return b
 }
 
-// Build returns the built complex material. Returns an error if name is empty, price is invalid (negative value or empty currency), or unit quantity is negative.
+// Build returns the built complex material. Returns an error if name is empty, price is invalid (negative value or empty currency),
+// unit quantity is negative, or waste or pack size is negative.
 func (b *ComplexMaterialBuilder) Build() (*ComplexMaterial, error) {
 	if b.complexMaterial.Name == "" {
 		return nil, errors.New("complex material name cannot be empty")
@@ -67,5 +93,8 @@ func (b *ComplexMaterialBuilder) Build() (*ComplexMaterial, error) {
 	if b.complexMaterial.UnitQuantity < 0 {
 		return nil, errors.New("complex material unit quantity cannot be negative")
 	}
+	if err := b.complexMaterial.CheckPurchase(); err != nil {
+		return nil, fmt.Errorf("complex material: %w", err)
+	}
 	return b.complexMaterial, nil
 }
@@ -57,3 +57,32 @@ func TestComplexMaterialBuilder_Build(t *testing.T) {
 		t.Errorf("CalculatePrice() = %v, want 35", c.CalculatePrice())
 	}
 }
+
+func TestComplexMaterial_PurchaseQuantity(t *testing.T) {
+	meas := NewMeasurableMaterial("Pipe 2m", "", *unit.NewPrice(10, "EUR"), *unit.NewMeasurableQuantity(2, unit.UnitMeter))
+	c := NewComplexMaterial("Pipes", "", *unit.NewPrice(0, "EUR"), 7, meas)
+	c.WastePercent = 10
+	c.PackSize = 4
+	// 7 + 10% = 7.7 → 8 units, 2 packs of 4.
+	if got := c.PurchaseQuantity(); got != 8 {
+		t.Errorf("PurchaseQuantity() = %d, want 8", got)
+	}
+	if got := c.CalculatePrice(); got != 160 {
+		t.Errorf("CalculatePrice() = %v, want 160", got)
+	}
+}
+
+func TestComplexMaterial_WasteAppliedOnce(t *testing.T) {
+	// Waste on both levels: only the units of the complex material get it (10 + 10% = 11 units of 2 m at 10 EUR/m).
+	meas := NewMeasurableMaterial("Pipe 2m", "", *unit.NewPrice(10, "EUR"), *unit.NewMeasurableQuantity(2, unit.UnitMeter))
+	meas.WastePercent = 10
+	meas.PackSize = 5
+	c := NewComplexMaterial("Pipes", "", *unit.NewPrice(0, "EUR"), 10, meas)
+	c.WastePercent = 10
+	if got := c.PurchaseQuantity(); got != 11 {
+		t.Errorf("PurchaseQuantity() = %d, want 11", got)
+	}
+	if got := c.CalculatePrice(); got != 220 {
+		t.Errorf("CalculatePrice() = %v, want 220 (11 × 20), not %v with the component waste and packs", got, 11*meas.CalculatePrice())
+	}
+}
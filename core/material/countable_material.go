@@ -3,11 +3,16 @@ package material
 import "explosio/core/unit"
 
 // CountableMaterial is a countable material (e.g. screws, pieces).
+// Quantity is the net quantity used; the price is charged on the purchased quantity (see PurchaseQuantity).
 type CountableMaterial struct {
-	Name        string
-	Description string
-	Price       unit.Price
-	Quantity    int
+	Name         string
+	Code         string `json:",omitempty" yaml:",omitempty"` // Article code (catalog or supplier): merges the material in the bill of materials
+	Description  string
+	Supplier     string `json:",omitempty" yaml:",omitempty"`
+	Price        unit.Price
+	Quantity     int
+	WastePercent float64 `json:",omitempty" yaml:",omitempty"` // Extra quantity for breakage and offcuts (e.g. 10)
+	PackSize     int     `json:",omitempty" yaml:",omitempty"` // Pieces per pack (e.g. box of 100); 0 = sold by the piece
 }
 
 // NewCountableMaterial creates a countable material (e.g. screws, pieces).
@@ -15,25 +20,45 @@ func NewCountableMaterial(name string, description string, price unit.Price, qua
 	return &CountableMaterial{Name: name, Description: description, Price: price, Quantity: quantity}
 }
 
-// CalculatePrice returns the total price of the material (unit price multiplied by quantity).
+// CalculatePrice returns the total price of the material (unit price multiplied by the purchased quantity).
 func (c *CountableMaterial) CalculatePrice() float64 {
-	return c.Price.Value * float64(c.Quantity)
+	return c.CalculateMoney().Float64()
 }
 
-// SetTotalPrice sets the total price and derives the unit price from quantity.
-// If Quantity is 0, unit price is set to 0 (avoids division by zero).
+// CalculateMoney returns the exact total price (unit price multiplied by the purchased quantity).
+func (c *CountableMaterial) CalculateMoney() unit.Money {
+	return c.Price.Money().MulInt(int64(c.PurchaseQuantity()))
+}
+
+// PurchaseQuantity returns the pieces to buy: Quantity plus waste, rounded up to whole pieces and packs.
+// Example: 250 screws with 5% waste in boxes of 100 → 300.
+func (c *CountableMaterial) PurchaseQuantity() int {
+	return int(purchaseQuantity(float64(c.Quantity), c.WastePercent, float64(c.PackSize), true))
+}
+
+// CheckPurchase returns an error if the waste percentage or the pack size is negative.
+func (c *CountableMaterial) CheckPurchase() error {
+	return checkPurchase(c.WastePercent, float64(c.PackSize))
+}
+
+// SetTotalPrice sets the total price and derives the unit price from the purchased quantity,
+// rounded half-even to the Money scale (4 decimals).
+// If the purchased quantity is 0, unit price is set to 0 (avoids division by zero).
 func (c *CountableMaterial) SetTotalPrice(totalPrice unit.Price) {
-	if c.Quantity == 0 {
+	q := c.PurchaseQuantity()
+	if q == 0 {
 		c.Price = unit.Price{Value: 0, Currency: totalPrice.Currency}
 		return
 	}
-	c.Price = unit.Price{
-		Value:    totalPrice.Value / float64(c.Quantity),
-		Currency: totalPrice.Currency,
-	}
+	c.Price = totalPrice.Money().Div(float64(q), unit.RoundHalfEven).Price()
 }
 
 // Clone returns a deep copy of the countable material.
 func (c *CountableMaterial) Clone() *CountableMaterial {
-	return NewCountableMaterial(c.Name, c.Description, c.Price, c.Quantity)
+	clone := NewCountableMaterial(c.Name, c.Description, c.Price, c.Quantity)
+	clone.Code = c.Code
+	clone.Supplier = c.Supplier
+	clone.WastePercent = c.WastePercent
+	clone.PackSize = c.PackSize
+	return clone
 }
@@ -3,6 +3,7 @@ package material
 import (
 	"errors"
 	"explosio/core/unit"
+	"fmt"
 )
 
 // CountableMaterialBuilder builds a countable material.
@@ -34,6 +35,30 @@ func (b *CountableMaterialBuilder) WithDescription(description string) *Countabl
 	return b
 }
 
+// WithCode sets the catalog code and returns the builder for chaining.
+func (b *CountableMaterialBuilder) WithCode(code string) *CountableMaterialBuilder {
+	b.countableMaterial.Code = code
+	return b
+}
+
+// WithWaste sets the waste percentage added to the purchased quantity and returns the builder for chaining.
+func (b *CountableMaterialBuilder) WithWaste(percent float64) *CountableMaterialBuilder {
+	b.countableMaterial.WastePercent = percent
+	return b
+}
+
+// WithPackSize sets the pack size (pieces per box) and returns the builder for chaining.
+func (b *CountableMaterialBuilder) WithPackSize(size int) *CountableMaterialBuilder {
+	b.countableMaterial.PackSize = size
+	return b
+}
+
+// WithSupplier sets the supplier (used by pricing discount rules) and returns the builder for chaining.
+func (b *CountableMaterialBuilder) WithSupplier(supplier string) *CountableMaterialBuilder {
+	b.countableMaterial.Supplier = supplier
+	return b
+}
+
 // WithPrice sets the price and returns the builder for chaining.
 func (b *CountableMaterialBuilder) WithPrice(price unit.Price) *CountableMaterialBuilder {
 	b.countableMaterial.Price = price
@@ -53,7 +78,8 @@ func (b *CountableMaterialBuilder) WithTotalPrice(totalPrice unit.Price) *Counta
 	return b
 }
 
-// Build returns the built countable material. Returns an error if name is empty, price is invalid (negative value or empty currency), or quantity is negative.
+// Build returns the built countable material. Returns an error if name is empty, price is invalid (negative value or empty currency),
+// quantity is negative, or waste or pack size is negative.
 func (b *CountableMaterialBuilder) Build() (*CountableMaterial, error) {
 	if b.countableMaterial.Name == "" {
 		return nil, errors.New("countable material name cannot be empty")
@@ -67,5 +93,8 @@ func (b *CountableMaterialBuilder) Build() (*CountableMaterial, error) {
 	if b.countableMaterial.Quantity < 0 {
 		return nil, errors.New("countable material quantity cannot be negative")
 	}
+	if err := b.countableMaterial.CheckPurchase(); err != nil {
+		return nil, fmt.Errorf("countable material: %w", err)
+	}
 	return b.countableMaterial, nil
 }
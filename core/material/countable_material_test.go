@@ -29,6 +29,13 @@ func TestCountableMaterial_SetTotalPrice(t *testing.T) {
 			t.Errorf("CalculatePrice() = %v, want 50", c.CalculatePrice())
 		}
 	})
+	t.Run("unit price is rounded to 4 decimals", func(t *testing.T) {
+		c := NewCountableMaterial("Screws", "", unit.Price{}, 3)
+		c.SetTotalPrice(*unit.NewPrice(10, "EUR"))
+		if c.Price.Value != 3.3333 {
+			t.Errorf("SetTotalPrice: unit price = %v, want 3.3333", c.Price.Value)
+		}
+	})
 	t.Run("quantity 0 sets unit price to 0", func(t *testing.T) {
 		c := NewCountableMaterial("Empty", "", unit.Price{}, 0)
 		c.SetTotalPrice(*unit.NewPrice(100, "EUR"))
@@ -79,3 +86,34 @@ func TestCountableMaterialBuilder_Build(t *testing.T) {
 		t.Errorf("CalculatePrice() = %v, want 100 (25 * 4)", c.CalculatePrice())
 	}
 }
+
+func TestCountableMaterial_PurchaseQuantity(t *testing.T) {
+	tests := []struct {
+		quantity int
+		waste    float64
+		pack     int
+		want     int
+	}{
+		{250, 0, 0, 250},
+		{250, 5, 0, 263},   // 262.5 rounded up to whole pieces
+		{250, 5, 100, 300}, // boxes of 100
+		{100, 10, 110, 110},
+		{0, 10, 100, 0},
+	}
+	for _, tt := range tests {
+		c := NewCountableMaterial("Screws", "", *unit.NewPrice(0.1, "EUR"), tt.quantity)
+		c.WastePercent, c.PackSize = tt.waste, tt.pack
+		if got := c.PurchaseQuantity(); got != tt.want {
+			t.Errorf("PurchaseQuantity(%d, %g%%, pack %d) = %d, want %d", tt.quantity, tt.waste, tt.pack, got, tt.want)
+		}
+	}
+
+	c := NewCountableMaterial("Screws", "", *unit.NewPrice(0.1, "EUR"), 250)
+	c.PackSize = 100
+	if got := c.CalculatePrice(); got != 30 {
+		t.Errorf("CalculatePrice() = %v, want 30 (3 boxes of 100)", got)
+	}
+	if _, err := NewCountableMaterialBuilder().WithName("Screws").WithPackSize(-1).Build(); err == nil {
+		t.Error("Build: expected an error for a negative pack size")
+	}
+}
@@ -3,11 +3,18 @@ package material
 import "explosio/core/unit"
 
 // MeasurableMaterial is a material with measurable quantity (e.g. 5 kg cement, 10 m cable).
+// Price is per PriceUnit (e.g. 12 EUR per kg); if PriceUnit is empty, the price is per unit of Quantity.
+// Quantity is the net quantity used; the price is charged on the purchased quantity (see PurchaseQuantity).
 type MeasurableMaterial struct {
-	Name        string
-	Description string
-	Price       unit.Price
-	Quantity    unit.MeasurableQuantity
+	Name         string
+	Code         string `json:",omitempty" yaml:",omitempty"`
+	Description  string
+	Supplier     string `json:",omitempty" yaml:",omitempty"`
+	Price        unit.Price
+	PriceUnit    unit.MeasurableUnit `json:",omitempty" yaml:",omitempty"`
+	Quantity     unit.MeasurableQuantity
+	WastePercent float64 `json:",omitempty" yaml:",omitempty"` // Extra quantity for offcuts and spillage (e.g. 10)
+	PackSize     float64 `json:",omitempty" yaml:",omitempty"` // Pack content in the unit of Quantity (e.g. 25 for 25 kg bags); 0 = sold loose
 }
 
 // NewMeasurableMaterial creates a material with measurable quantity (e.g. kg, m).
@@ -15,25 +22,81 @@ func NewMeasurableMaterial(name string, description string, price unit.Price, qu
 	return &MeasurableMaterial{Name: name, Description: description, Price: price, Quantity: quantity}
 }
 
-// CalculatePrice returns the total price of the material (unit price multiplied by quantity).
+// CalculatePrice returns the total price of the material (unit price multiplied by the purchased quantity in the price unit).
 func (m *MeasurableMaterial) CalculatePrice() float64 {
-	return m.Price.Value * m.Quantity.Value
+	return m.CalculateMoney().Float64()
 }
 
-// SetTotalPrice sets the total price and derives the unit price from quantity.
-// If Quantity.Value is 0, unit price is set to 0 (avoids division by zero).
+// CalculateMoney returns the exact total price (unit price multiplied by the purchased quantity in the price unit), rounded half-even to 4 decimals.
+func (m *MeasurableMaterial) CalculateMoney() unit.Money {
+	return m.Price.Money().Mul(m.billedQuantity(), unit.RoundHalfEven)
+}
+
+// PriceBasis returns the unit the price refers to: PriceUnit, or the quantity unit if not set.
+func (m *MeasurableMaterial) PriceBasis() unit.MeasurableUnit {
+	if m.PriceUnit != "" {
+		return m.PriceUnit
+	}
+	return m.Quantity.Unit
+}
+
+// PurchaseQuantity returns the quantity to buy, in the unit of Quantity: Quantity plus waste, rounded up to whole packs.
+// Example: 60 kg with 10% waste in 25 kg bags → 75 kg.
+func (m *MeasurableMaterial) PurchaseQuantity() float64 {
+	return purchaseQuantity(m.Quantity.Value, m.WastePercent, m.PackSize, false)
+}
+
+// CheckPurchase returns an error if the waste percentage or the pack size is negative.
+func (m *MeasurableMaterial) CheckPurchase() error {
+	return checkPurchase(m.WastePercent, m.PackSize)
+}
+
+// CheckUnits returns an error if the quantity cannot be converted to the price unit (e.g. price per m² with quantity in kg).
+func (m *MeasurableMaterial) CheckUnits() error {
+	_, err := m.Quantity.In(m.PriceBasis())
+	return err
+}
+
+// NetMoney returns the exact price of the net quantity, without waste and packs. A complex material uses it for
+// the content of one unit, since its own waste and packs apply to the units bought.
+func (m *MeasurableMaterial) NetMoney() unit.Money {
+	return m.Price.Money().Mul(m.InPriceUnit(m.Quantity.Value), unit.RoundHalfEven)
+}
+
+// billedQuantity returns the purchased quantity converted to the price unit (e.g. 500 g priced per kg → 0.5).
+func (m *MeasurableMaterial) billedQuantity() float64 {
+	return m.InPriceUnit(m.PurchaseQuantity())
+}
+
+// InPriceUnit converts q from the unit of Quantity to the price unit.
+// If the units are incompatible, q is returned as is; Validate reports the error.
+func (m *MeasurableMaterial) InPriceUnit(q float64) float64 {
+	v, err := unit.ConvertUnit(q, m.Quantity.Unit, m.PriceBasis())
+	if err != nil {
+		return q
+	}
+	return v
+}
+
+// SetTotalPrice sets the total price and derives the price per price unit from the purchased quantity,
+// rounded half-even to the Money scale (4 decimals).
+// If the quantity is 0, unit price is set to 0 (avoids division by zero).
 func (m *MeasurableMaterial) SetTotalPrice(totalPrice unit.Price) {
-	if m.Quantity.Value == 0 {
+	q := m.billedQuantity()
+	if q == 0 {
 		m.Price = unit.Price{Value: 0, Currency: totalPrice.Currency}
 		return
 	}
-	m.Price = unit.Price{
-		Value:    totalPrice.Value / m.Quantity.Value,
-		Currency: totalPrice.Currency,
-	}
+	m.Price = totalPrice.Money().Div(q, unit.RoundHalfEven).Price()
 }
 
 // Clone returns a deep copy of the measurable material.
 func (m *MeasurableMaterial) Clone() *MeasurableMaterial {
-	return NewMeasurableMaterial(m.Name, m.Description, m.Price, m.Quantity)
+	clone := NewMeasurableMaterial(m.Name, m.Description, m.Price, m.Quantity)
+	clone.Code = m.Code
+	clone.Supplier = m.Supplier
+	clone.PriceUnit = m.PriceUnit
+	clone.WastePercent = m.WastePercent
+	clone.PackSize = m.PackSize
+	return clone
 }
@@ -3,6 +3,7 @@ package material
 import (
 	"errors"
 	"explosio/core/unit"
+	"fmt"
 )
 
 // MeasurableMaterialBuilder builds a measurable material.
@@ -34,12 +35,43 @@ func (b *MeasurableMaterialBuilder) WithDescription(description string) *Measura
 	return b
 }
 
+// WithCode sets the catalog code and returns the builder for chaining.
+func (b *MeasurableMaterialBuilder) WithCode(code string) *MeasurableMaterialBuilder {
+	b.measurableMaterial.Code = code
+	return b
+}
+
+// WithWaste sets the waste percentage added to the purchased quantity and returns the builder for chaining.
+func (b *MeasurableMaterialBuilder) WithWaste(percent float64) *MeasurableMaterialBuilder {
+	b.measurableMaterial.WastePercent = percent
+	return b
+}
+
+// WithPackSize sets the pack size (content in the quantity unit) and returns the builder for chaining.
+func (b *MeasurableMaterialBuilder) WithPackSize(size float64) *MeasurableMaterialBuilder {
+	b.measurableMaterial.PackSize = size
+	return b
+}
+
+// WithSupplier sets the supplier (used by pricing discount rules) and returns the builder for chaining.
+func (b *MeasurableMaterialBuilder) WithSupplier(supplier string) *MeasurableMaterialBuilder {
+	b.measurableMaterial.Supplier = supplier
+	return b
+}
+
 // WithPrice sets the price and returns the builder for chaining.
 func (b *MeasurableMaterialBuilder) WithPrice(price unit.Price) *MeasurableMaterialBuilder {
 	b.measurableMaterial.Price = price
 	return b
 }
 
+// WithPriceUnit sets the unit the price refers to (e.g. kg for a price per kg) and returns the builder for chaining.
+// Set it before WithTotalPrice, which derives the price per this unit.
+func (b *MeasurableMaterialBuilder) WithPriceUnit(u unit.MeasurableUnit) *MeasurableMaterialBuilder {
+	b.measurableMaterial.PriceUnit = u
+	return b
+}
+
 // WithQuantity sets the quantity and returns the builder for chaining.
 func (b *MeasurableMaterialBuilder) WithQuantity(quantity unit.MeasurableQuantity) *MeasurableMaterialBuilder {
 	b.measurableMaterial.Quantity = quantity
@@ -53,7 +85,8 @@ func (b *MeasurableMaterialBuilder) WithTotalPrice(totalPrice unit.Price) *Measu
 	return b
 }
 
-// Build returns the built measurable material. Returns an error if name is empty, price is invalid (negative value or empty currency), or quantity is negative.
+// Build returns the built measurable material. Returns an error if name is empty, price is invalid (negative value or empty currency),
+// quantity, waste or pack size is negative, or the quantity cannot be converted to the price unit.
 func (b *MeasurableMaterialBuilder) Build() (*MeasurableMaterial, error) {
 	if b.measurableMaterial.Name == "" {
 		return nil, errors.New("measurable material name cannot be empty")
@@ -67,5 +100,11 @@ func (b *MeasurableMaterialBuilder) Build() (*MeasurableMaterial, error) {
 	if b.measurableMaterial.Quantity.Value < 0 {
 		return nil, errors.New("measurable material quantity cannot be negative")
 	}
+	if err := b.measurableMaterial.CheckUnits(); err != nil {
+		return nil, fmt.Errorf("measurable material price unit: %w", err)
+	}
+	if err := b.measurableMaterial.CheckPurchase(); err != nil {
+		return nil, fmt.Errorf("measurable material: %w", err)
+	}
 	return b.measurableMaterial, nil
 }
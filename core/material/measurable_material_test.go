@@ -84,3 +84,52 @@ func TestMeasurableMaterialBuilder_Build(t *testing.T) {
 		t.Errorf("CalculatePrice() = %v, want 200 (20 * 10)", m.CalculatePrice())
 	}
 }
+
+func TestMeasurableMaterial_PriceUnit(t *testing.T) {
+	m := NewMeasurableMaterial("Pigment", "", *unit.NewPrice(40, "EUR"), *unit.NewMeasurableQuantity(250, unit.UnitGram))
+	m.PriceUnit = unit.UnitKilogram
+	// 250 g at 40 EUR/kg, not 250 × 40.
+	if got := m.CalculatePrice(); got != 10 {
+		t.Errorf("CalculatePrice() = %v, want 10", got)
+	}
+	m.SetTotalPrice(*unit.NewPrice(12, "EUR"))
+	if m.Price.Value != 48 {
+		t.Errorf("SetTotalPrice: price per kg = %v, want 48", m.Price.Value)
+	}
+	if err := m.CheckUnits(); err != nil {
+		t.Errorf("CheckUnits() = %v", err)
+	}
+
+	m.PriceUnit = unit.UnitSquareMeter
+	if err := m.CheckUnits(); err == nil {
+		t.Error("expected an error for a price per m² with quantity in g")
+	}
+	_, err := NewMeasurableMaterialBuilder().WithName("Paint").WithPriceUnit(unit.UnitSquareMeter).
+		WithQuantity(*unit.NewMeasurableQuantity(5, unit.UnitKilogram)).Build()
+	if err == nil {
+		t.Error("Build: expected an error for incompatible price unit")
+	}
+}
+
+func TestMeasurableMaterial_PurchaseQuantity(t *testing.T) {
+	// 60 kg of cement with 10% waste, sold in 25 kg bags at 8 EUR per bag (0.32 EUR/kg).
+	m := NewMeasurableMaterial("Cement", "", *unit.NewPrice(0.32, "EUR"), *unit.NewMeasurableQuantity(60, unit.UnitKilogram))
+	m.WastePercent = 10
+	m.PackSize = 25
+	if got := m.PurchaseQuantity(); got != 75 {
+		t.Errorf("PurchaseQuantity() = %v, want 75", got)
+	}
+	if got := m.CalculatePrice(); got != 24 {
+		t.Errorf("CalculatePrice() = %v, want 24 (3 bags)", got)
+	}
+	if m.Quantity.Value != 60 {
+		t.Errorf("net quantity = %v, want 60", m.Quantity.Value)
+	}
+
+	// Waste without packs: 12 m² of tiles + 10% at 30 EUR/m².
+	tiles := NewMeasurableMaterial("Tiles", "", *unit.NewPrice(30, "EUR"), *unit.NewMeasurableQuantity(12, unit.UnitSquareMeter))
+	tiles.WastePercent = 10
+	if got := tiles.CalculatePrice(); got != 396 {
+		t.Errorf("CalculatePrice() = %v, want 396", got)
+	}
+}
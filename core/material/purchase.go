@@ -0,0 +1,43 @@
+package material
+
+import (
+	"errors"
+	"math"
+)
+
+// packEpsilon absorbs float error when rounding up (e.g. 100 × 1.1 = 110.00000000000001 is 110, not 111).
+const packEpsilon = 1e-9
+
+// purchaseQuantity returns the quantity to buy for a net quantity: the waste percentage is added, then the result
+// is rounded up to whole packs of packSize (if positive). With wholeUnits, it is rounded up to whole units
+// even without packs (pieces cannot be split).
+func purchaseQuantity(net, wastePercent, packSize float64, wholeUnits bool) float64 {
+	q := net
+	if wastePercent > 0 {
+		q *= 1 + wastePercent/100
+	}
+	return RoundPurchase(q, packSize, wholeUnits)
+}
+
+// RoundPurchase rounds a quantity that already includes waste up to whole units (with wholeUnits) and then
+// to whole packs of packSize (if positive). The bill of materials uses it to round merged quantities once.
+func RoundPurchase(q, packSize float64, wholeUnits bool) float64 {
+	if wholeUnits {
+		q = math.Ceil(q - packEpsilon)
+	}
+	if packSize > 0 && q > 0 {
+		q = math.Ceil(q/packSize-packEpsilon) * packSize
+	}
+	return q
+}
+
+// checkPurchase returns an error if the waste percentage or the pack size is negative.
+func checkPurchase(wastePercent, packSize float64) error {
+	if wastePercent < 0 {
+		return errors.New("waste percent cannot be negative")
+	}
+	if packSize < 0 {
+		return errors.New("pack size cannot be negative")
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package core
+
+import (
+	"fmt"
+	"math"
+
+	"explosio/core/unit"
+)
+
+// ThreePointEstimate holds optimistic, most likely and pessimistic durations of an activity (PERT).
+// The scheduling methods keep using Activity.Duration; the estimate is used by PERT analysis and by
+// Monte Carlo simulation, which samples it with Distribution (default PERT-beta).
+type ThreePointEstimate struct {
+	Optimistic   unit.Duration
+	MostLikely   unit.Duration
+	Pessimistic  unit.Duration
+	Distribution Distribution `json:",omitempty" yaml:",omitempty"`
+}
+
+// NewThreePointEstimate creates an estimate with the three durations in the same unit.
+func NewThreePointEstimate(optimistic, mostLikely, pessimistic float64, u unit.DurationUnit) *ThreePointEstimate {
+	return &ThreePointEstimate{
+		Optimistic:  *unit.NewDuration(optimistic, u),
+		MostLikely:  *unit.NewDuration(mostLikely, u),
+		Pessimistic: *unit.NewDuration(pessimistic, u),
+	}
+}
+
+// SetEstimate sets the three-point estimate of the activity (nil removes it).
+func (a *Activity) SetEstimate(e *ThreePointEstimate) {
+	a.Estimate = e
+}
+
+// hoursWith returns the optimistic, most likely and pessimistic durations in hours.
+func (e *ThreePointEstimate) hoursWith(conv unit.DurationConversion) (float64, float64, float64) {
+	return e.Optimistic.ToHoursWith(conv), e.MostLikely.ToHoursWith(conv), e.Pessimistic.ToHoursWith(conv)
+}
+
+// ExpectedHours returns the PERT expected duration (o + 4m + p) / 6 in hours.
+func (e *ThreePointEstimate) ExpectedHours(conv unit.DurationConversion) float64 {
+	o, m, p := e.hoursWith(conv)
+	return (o + 4*m + p) / 6
+}
+
+// VarianceHours returns the PERT variance ((p - o) / 6)² in hours².
+func (e *ThreePointEstimate) VarianceHours(conv unit.DurationConversion) float64 {
+	o, _, p := e.hoursWith(conv)
+	sd := (p - o) / 6
+	return sd * sd
+}
+
+// valid returns true if 0 <= optimistic <= most likely <= pessimistic and the distribution is known.
+func (e *ThreePointEstimate) valid() bool {
+	o, m, p := e.hoursWith(unit.DefaultConversion())
+	return o >= 0 && o <= m && m <= p && IsValidDistribution(e.Distribution)
+}
+
+// PERTResult holds the PERT analysis of a project: expected duration and variance along the critical path.
+type PERTResult struct {
+	ExpectedHours float64     // Expected project duration (hours)
+	Variance      float64     // Variance of the project duration (hours²)
+	CriticalPath  []*Activity // Critical path with expected durations, ordered by early start
+	Expected      map[*Activity]float64
+	Variances     map[*Activity]float64
+}
+
+// StdDev returns the standard deviation of the project duration (hours).
+func (r *PERTResult) StdDev() float64 {
+	return math.Sqrt(r.Variance)
+}
+
+// Probability returns the probability of finishing within the given hours, assuming a normal distribution
+// of the project duration.
+func (r *PERTResult) Probability(hours float64) float64 {
+	sd := r.StdDev()
+	if sd == 0 {
+		if hours+slackEpsilon >= r.ExpectedHours {
+			return 1
+		}
+		return 0
+	}
+	return 0.5 * (1 + math.Erf((hours-r.ExpectedHours)/(sd*math.Sqrt2)))
+}
+
+// PERT runs the PERT analysis with calendar-time conversion; see Project.PERT for the project settings.
+// Activities without an estimate use their Duration with zero variance.
+func (a *Activity) PERT() *PERTResult {
+	return a.pert(defaultScheduleOptions())
+}
+
+func (a *Activity) pert(opts scheduleOptions) *PERTResult {
+	r := &PERTResult{
+		Expected:  make(map[*Activity]float64),
+		Variances: make(map[*Activity]float64),
+	}
+	for _, act := range a.GetActivities() {
+		if act.Estimate != nil {
+			r.Expected[act] = act.Estimate.ExpectedHours(opts.conv)
+			r.Variances[act] = act.Estimate.VarianceHours(opts.conv)
+		} else {
+			r.Expected[act] = opts.hours(act.Duration)
+		}
+	}
+	opts.durations = r.Expected
+
+	m, projectEnd := a.calculateSlack(opts)
+	r.ExpectedHours = projectEnd
+	r.CriticalPath = criticalPathFromSlack(m, 0)
+	r.Variance = a.criticalVariance(opts, r.CriticalPath, r.Variances)
+	return r
+}
+
+// criticalVariance returns the largest sum of variances over the chains of critical activities
+// (with tied critical paths, the most uncertain one is used).
+func (a *Activity) criticalVariance(opts scheduleOptions, path []*Activity, variances map[*Activity]float64) float64 {
+	critical := make(map[*Activity]bool)
+	for _, act := range path {
+		critical[act] = true
+	}
+	all := make(map[*Activity]bool)
+	preds := make(map[*Activity][]cpmLink)
+	a.buildCPMGraph(nil, all, preds, opts)
+
+	chain := make(map[*Activity]float64)
+	best := 0.0
+	for _, act := range topoOrder(all, preds) {
+		if !critical[act] {
+			continue
+		}
+		v := 0.0
+		for _, l := range preds[act] {
+			if critical[l.pred] && chain[l.pred] > v {
+				v = chain[l.pred]
+			}
+		}
+		chain[act] = v + variances[act]
+		if chain[act] > best {
+			best = chain[act]
+		}
+	}
+	return best
+}
+
+// PERT runs the PERT analysis with the project settings (conversion policy, constraints, deadline).
+func (p *Project) PERT() *PERTResult {
+	return p.Root.pert(p.scheduleOptions())
+}
+
+// PERTProbability returns the PERT analysis and the probability of finishing by the end of date by,
+// with the project starting on start. With a calendar, only working time counts.
+func (p *Project) PERTProbability(start, by unit.Date) (*PERTResult, float64) {
+	opts := p.scheduleOptions()
+	opts.start = &start
+	r := p.Root.pert(opts)
+	return r, r.Probability(opts.hoursAtEndOf(by))
+}
+
+// PrintPERT prints the estimates of the critical path, the expected duration and its standard deviation.
+func PrintPERT(r *PERTResult) {
+	fmt.Println("--------------------------------")
+	fmt.Println("   PERT Analysis")
+	fmt.Println("--------------------------------")
+	fmt.Printf("%-24s %10s %10s %10s %10s %10s\n", "Critical activity", "O", "M", "P", "Exp (h)", "Var (h²)")
+	for _, act := range r.CriticalPath {
+		name := act.Name
+		if len(name) > 24 {
+			name = name[:21] + "..."
+		}
+		if act.Estimate == nil {
+			fmt.Printf("%-24s %10s %10s %10s %10.1f %10.1f\n", name, "-", "-", "-", r.Expected[act], 0.0)
+			continue
+		}
+		e := act.Estimate
+		fmt.Printf("%-24s %10s %10s %10s %10.1f %10.1f\n", name,
+			formatDuration(e.Optimistic), formatDuration(e.MostLikely), formatDuration(e.Pessimistic),
+			r.Expected[act], r.Variances[act])
+	}
+	fmt.Println("--------------------------------")
+	fmt.Printf("Expected duration: %.1f hours\n", r.ExpectedHours)
+	fmt.Printf("Standard deviation: %.1f hours\n", r.StdDev())
+}
+
+// formatDuration formats a duration as "<value> <unit>" (e.g. "3 day").
+func formatDuration(d unit.Duration) string {
+	return fmt.Sprintf("%g %s", d.Value, d.Unit)
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"bytes"
+	"explosio/core/unit"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestThreePointEstimate_ExpectedAndVariance(t *testing.T) {
+	e := NewThreePointEstimate(1, 2, 9, unit.DurationUnitHour)
+	conv := unit.CalendarTimeConversion()
+	if got := e.ExpectedHours(conv); got != 3 {
+		t.Errorf("ExpectedHours = %v, want 3", got)
+	}
+	if got := e.VarianceHours(conv); math.Abs(got-64.0/36) > 1e-9 {
+		t.Errorf("VarianceHours = %v, want %v", got, 64.0/36)
+	}
+}
+
+func TestPERT_CriticalPathVariance(t *testing.T) {
+	// Root (0d) with Plaster (4d expected, var from 2-4-6) and Tiling (3d, no estimate).
+	root, plaster, tiling := buildLinkTestTree()
+	plaster.SetEstimate(NewThreePointEstimate(2, 4, 6, unit.DurationUnitDay))
+
+	r := root.PERT()
+	if r.ExpectedHours != 96 {
+		t.Errorf("ExpectedHours = %v, want 96", r.ExpectedHours)
+	}
+	// (6d - 2d) / 6 = 16h: variance 256h²
+	if math.Abs(r.Variance-256) > 1e-9 {
+		t.Errorf("Variance = %v, want 256", r.Variance)
+	}
+	for _, a := range r.CriticalPath {
+		if a == tiling {
+			t.Error("Tiling should not be on the PERT critical path")
+		}
+	}
+	if got := r.Probability(96); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("Probability(expected) = %v, want 0.5", got)
+	}
+	if got := r.Probability(96 + 16); math.Abs(got-0.8413) > 1e-3 {
+		t.Errorf("Probability(expected + 1 sd) = %v, want ~0.8413", got)
+	}
+}
+
+func TestPERT_ExpectedDurationDrivesPath(t *testing.T) {
+	// Tiling (3d) has a long tail: its expected duration (3 + 4*3 + 15)/6 = 5d makes it critical.
+	root, plaster, tiling := buildLinkTestTree()
+	tiling.SetEstimate(NewThreePointEstimate(3, 3, 15, unit.DurationUnitDay))
+
+	r := root.PERT()
+	if r.ExpectedHours != 120 {
+		t.Errorf("ExpectedHours = %v, want 120", r.ExpectedHours)
+	}
+	for _, a := range r.CriticalPath {
+		if a == plaster {
+			t.Error("Plaster should not be on the PERT critical path")
+		}
+	}
+	// Deterministic schedule is unchanged.
+	if got := root.CalculateSlack()[tiling].Slack; got != 24 {
+		t.Errorf("Tiling slack = %v, want 24", got)
+	}
+}
+
+func TestPERT_TiedPathsUseLargestVariance(t *testing.T) {
+	root, plaster, tiling := buildLinkTestTree()
+	plaster.SetEstimate(NewThreePointEstimate(3, 4, 5, unit.DurationUnitDay))
+	tiling.Duration = *unit.NewDuration(4, unit.DurationUnitDay)
+	tiling.SetEstimate(NewThreePointEstimate(1, 4, 7, unit.DurationUnitDay))
+
+	r := root.PERT()
+	// Both branches are critical: the variance is the one of Tiling ((7-1)/6 days = 24h), not the sum.
+	if math.Abs(r.Variance-576) > 1e-9 {
+		t.Errorf("Variance = %v, want 576", r.Variance)
+	}
+}
+
+func TestProject_PERTProbability(t *testing.T) {
+	root, plaster, _ := buildLinkTestTree()
+	plaster.SetEstimate(NewThreePointEstimate(2, 4, 6, unit.DurationUnitDay))
+	proj := NewProject(root)
+
+	start := unit.NewDate(2026, time.March, 2)
+	// End of March 5 = 96h from the start: the expected duration.
+	_, p := proj.PERTProbability(start, unit.NewDate(2026, time.March, 5))
+	if math.Abs(p-0.5) > 1e-9 {
+		t.Errorf("probability = %v, want 0.5", p)
+	}
+}
+
+func TestThreePointEstimate_ValidationAndRoundTrip(t *testing.T) {
+	root, plaster, tiling := buildLinkTestTree()
+	plaster.SetEstimate(NewThreePointEstimate(5, 4, 6, unit.DurationUnitDay))
+	if r := root.Validate(); r.Valid() {
+		t.Error("expected an error for an unordered estimate")
+	}
+	plaster.SetEstimate(NewThreePointEstimate(2, 4, 6, unit.DurationUnitDay))
+	tiling.SetEstimate(NewThreePointEstimate(16, 24, 40, unit.DurationUnitHour))
+
+	var buf bytes.Buffer
+	if err := NewProject(root).WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ReadJSON(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := loaded.Root.Activities[1].Estimate
+	if e == nil || e.Pessimistic.Value != 40 || e.Pessimistic.Unit != unit.DurationUnitHour {
+		t.Errorf("Tiling estimate = %+v, want pessimistic 40 hour", e)
+	}
+	if math.Abs(loaded.Root.PERT().Variance-root.PERT().Variance) > 1e-9 {
+		t.Error("PERT variance changed after round trip")
+	}
+}
@@ -0,0 +1,38 @@
+package core
+
+import "explosio/core/resource"
+
+// This file contains the resource pool methods on Project.
+
+// AddResource adds a resource to the project pool (assigning an ID if it has none) and returns it.
+func (p *Project) AddResource(r *resource.Resource) *resource.Resource {
+	if r.ID == "" {
+		r.ID = resource.NewResourceID()
+	}
+	p.Resources = append(p.Resources, r)
+	return r
+}
+
+// FindResource returns the pool resource with the given ID, or nil if not found.
+func (p *Project) FindResource(id string) *resource.Resource {
+	for _, r := range p.Resources {
+		if r.ID == id {
+			return r
+		}
+	}
+	return nil
+}
+
+// ResourceAssignments returns the activities that have at least one assignment of the resource.
+func (p *Project) ResourceAssignments(r *resource.Resource) []*Activity {
+	var result []*Activity
+	for _, act := range p.Root.GetActivities() {
+		for _, as := range act.Assignments {
+			if as.Resource == r {
+				result = append(result, act)
+				break
+			}
+		}
+	}
+	return result
+}
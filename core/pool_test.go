@@ -0,0 +1,124 @@
+package core
+
+import (
+	"bytes"
+	"explosio/core/resource"
+	"explosio/core/unit"
+	"strings"
+	"testing"
+)
+
+// buildPoolTestProject returns a project with a plumber in the pool assigned to two activities.
+func buildPoolTestProject() (*Project, *resource.Resource, *Activity, *Activity) {
+	root := NewActivity("Root", "", *unit.NewDuration(0, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	pipes := NewActivity("Pipes", "", *unit.NewDuration(2, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	drain := NewActivity("Drain", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	root.AddActivity(pipes)
+	root.AddActivity(drain)
+
+	proj := NewProject(root)
+	plumber := proj.AddResource(resource.NewResource("Mario", "Plumber", *unit.NewPrice(40, "EUR")))
+	pipes.Assign(plumber, 1, 16)
+	drain.Assign(plumber, 0.5, 4)
+	return proj, plumber, pipes, drain
+}
+
+func TestPool_RateChangeUpdatesAssignments(t *testing.T) {
+	proj, plumber, pipes, drain := buildPoolTestProject()
+	if got := proj.Root.CalculatePrice(); got != 800 {
+		t.Errorf("price = %v, want 800", got)
+	}
+	plumber.Rate.Value = 50
+	if got := pipes.CalculatePrice() + drain.CalculatePrice(); got != 1000 {
+		t.Errorf("price after rate change = %v, want 1000", got)
+	}
+	cb := proj.Root.CostBreakdown()
+	if cb.Human != 1000 || cb.Assets != 0 {
+		t.Errorf("breakdown = %+v, want Human 1000", cb)
+	}
+	plumber.Kind = resource.KindAsset
+	if cb := proj.Root.CostBreakdown(); cb.Assets != 1000 {
+		t.Errorf("breakdown with asset kind = %+v, want Assets 1000", cb)
+	}
+	if got := proj.ResourceAssignments(plumber); len(got) != 2 {
+		t.Errorf("ResourceAssignments = %d activities, want 2", len(got))
+	}
+}
+
+func TestPool_RoundTrip(t *testing.T) {
+	for _, format := range []string{"json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			proj, plumber, _, _ := buildPoolTestProject()
+			plumber.MaxUnits = 2
+			plumber.Calendar = unit.NewCalendar()
+
+			var buf bytes.Buffer
+			var read *Project
+			var err error
+			if format == "json" {
+				if err = proj.WriteJSON(&buf); err != nil {
+					t.Fatal(err)
+				}
+				read, err = ReadJSON(&buf)
+			} else {
+				if err = proj.WriteYAML(&buf); err != nil {
+					t.Fatal(err)
+				}
+				read, err = ReadYAML(&buf)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(read.Resources) != 1 {
+				t.Fatalf("pool = %d resources, want 1", len(read.Resources))
+			}
+			r := read.FindResource(plumber.ID)
+			if r == nil || r.Role != "Plumber" || r.MaxUnits != 2 || r.Calendar == nil {
+				t.Fatalf("resource = %+v, want the plumber", r)
+			}
+			pipes, drain := read.Root.Activities[0], read.Root.Activities[1]
+			if pipes.Assignments[0].Resource != r || drain.Assignments[0].Resource != r {
+				t.Error("assignments should share the pool resource")
+			}
+			if drain.Assignments[0].Units != 0.5 || drain.Assignments[0].WorkHours != 4 {
+				t.Errorf("drain assignment = %+v, want 0.5 units, 4 hours", drain.Assignments[0])
+			}
+			if got := read.Root.CalculatePrice(); got != 800 {
+				t.Errorf("price = %v, want 800", got)
+			}
+		})
+	}
+}
+
+func TestPool_ReadUnknownResource(t *testing.T) {
+	data := `{"version":"1.0","root":{"ID":"r","Name":"Root","Assignments":[{"Resource":"missing","Units":1,"WorkHours":8}]}}`
+	_, err := ReadJSON(strings.NewReader(data))
+	if err == nil || !strings.Contains(err.Error(), "unknown resource ID") {
+		t.Errorf("err = %v, want unknown resource ID", err)
+	}
+}
+
+func TestPool_ResourceNotInPool(t *testing.T) {
+	proj, _, pipes, _ := buildPoolTestProject()
+	pipes.Assign(resource.NewResource("Luigi", "Electrician", *unit.NewPrice(35, "EUR")), 1, 8)
+
+	if err := proj.WriteJSON(&bytes.Buffer{}); err == nil {
+		t.Error("expected an error writing an assignment outside the pool")
+	}
+	if r := proj.Validate(); r.Valid() {
+		t.Error("expected a validation error for a resource outside the pool")
+	}
+}
+
+func TestPool_CloneSharesResource(t *testing.T) {
+	proj, plumber, _, _ := buildPoolTestProject()
+	clone := proj.Root.Clone()
+	as := clone.Activities[0].Assignments[0]
+	if as.Resource != plumber {
+		t.Error("cloned assignment should reference the same pool resource")
+	}
+	if as == proj.Root.Activities[0].Assignments[0] {
+		t.Error("cloned assignment should be a copy")
+	}
+}
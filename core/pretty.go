@@ -3,9 +3,11 @@ package core
 
 import (
 	"explosio/core/material"
+	"explosio/core/resource"
 	"explosio/core/resource/asset"
 	"explosio/core/resource/human"
 	"fmt"
+	"strings"
 )
 
 // ANSI color codes for terminal output.
@@ -63,6 +65,9 @@ func prettyPrintCountableMaterials(materials []*material.CountableMaterial, pref
 		connector := newConnector(showConnector, "", isLastItem)
 		price := fmt.Sprintf("%.2f %s", m.CalculatePrice(), m.Price.Currency)
 		quantity := fmt.Sprintf("%d", m.Quantity)
+		if bought := m.PurchaseQuantity(); bought != m.Quantity {
+			quantity += fmt.Sprintf(", buy %d", bought)
+		}
 		row := "🔢 " + m.Name + " [" + blue1 + price + reset + " - " + blue2 + quantity + reset + "]"
 		fmt.Println(prefix + connector + row)
 	}
@@ -75,6 +80,12 @@ func prettyPrintMeasurableMaterials(materials []*material.MeasurableMaterial, pr
 		connector := newConnector(showConnector, "", isLastItem)
 		price := fmt.Sprintf("%.2f %s", m.CalculatePrice(), m.Price.Currency)
 		quantity := fmt.Sprintf("%.0f%s", m.Quantity.Value, m.Quantity.Unit)
+		if bought := m.PurchaseQuantity(); bought != m.Quantity.Value {
+			quantity += fmt.Sprintf(", buy %g%s", bought, m.Quantity.Unit)
+		}
+		if m.PriceBasis() != m.Quantity.Unit {
+			quantity += fmt.Sprintf(" @ %.2f %s/%s", m.Price.Value, m.Price.Currency, m.PriceUnit)
+		}
 		row := "📏 " + m.Name + " [" + blue1 + price + reset + " - " + blue2 + quantity + reset + "]"
 		fmt.Println(prefix + connector + row)
 	}
@@ -104,6 +115,21 @@ func prettyPrintAssets(assets []*asset.Asset, prefix string, showConnector bool)
 	}
 }
 
+// prettyPrintAssignments prints the list of pool resource assignments with prefix and tree connectors.
+func prettyPrintAssignments(assignments []*resource.Assignment, prefix string, showConnector bool) {
+	for i, as := range assignments {
+		if as.Resource == nil {
+			continue
+		}
+		isLastItem := i == len(assignments)-1
+		connector := newConnector(showConnector, "", isLastItem)
+		price := fmt.Sprintf("%.2f %s", as.CalculatePrice(), as.Resource.Rate.Currency)
+		work := fmt.Sprintf("%g h @ %g units", as.WorkHours, as.Units)
+		row := "👥 " + as.Resource.Name + " [" + blue1 + price + reset + " - " + blue2 + work + reset + "]"
+		fmt.Println(prefix + connector + row)
+	}
+}
+
 // prettyPrintRecursive walks the tree in depth and prints activities (with critical path icon) and materials.
 func prettyPrintRecursive(activities []*Activity, prefix string, showConnector bool, criticalSet map[*Activity]bool, slackMap map[*Activity]SlackInfo) {
 	for i, activity := range activities {
@@ -123,9 +149,11 @@ func prettyPrintRecursive(activities []*Activity, prefix string, showConnector b
 			icon = "⏱"
 		}
 		row := icon + " " + activity.Name + ownFmt + totalFmt
-		if slackMap != nil && criticalSet != nil && !criticalSet[activity] {
-			if info, ok := slackMap[activity]; ok && info.Slack >= 0.5 {
-				row += fmt.Sprintf(" [slack: %.0fh]", info.Slack)
+		if slackMap != nil && criticalSet != nil {
+			if info, ok := slackMap[activity]; ok && !criticalSet[activity] && info.TotalFloat >= 0.5 {
+				row += fmt.Sprintf(" [slack: %.0fh, free: %.0fh]", info.TotalFloat, info.FreeFloat)
+			} else if ok && info.TotalFloat <= -0.5 {
+				row += fmt.Sprintf(" [late: %.0fh]", -info.TotalFloat)
 			}
 		}
 		fmt.Println(prefix + connector + row)
@@ -135,6 +163,7 @@ func prettyPrintRecursive(activities []*Activity, prefix string, showConnector b
 		prettyPrintMeasurableMaterials(activity.MeasurableMaterials, childPrefix, true)
 		prettyPrintHumanResources(activity.HumanResources, childPrefix, true)
 		prettyPrintAssets(activity.Assets, childPrefix, true)
+		prettyPrintAssignments(activity.Assignments, childPrefix, true)
 		prettyPrintRecursive(activity.Activities, childPrefix, true, criticalSet, slackMap)
 	}
 }
@@ -166,12 +195,36 @@ func PrettyPrintWithSlack(activities []*Activity, criticalPath []*Activity, slac
 	fmt.Println("📦: Complex material")
 	fmt.Println("🔢: Countable material")
 	fmt.Println("📏: Measurable material")
+	fmt.Println("👥: Pool resource assignment")
 	fmt.Println("[]: Own price and duration (blue variants)")
 	fmt.Println("(): Total price and duration (red variants)")
 	fmt.Println("<>: Measurable material in complex material")
-	fmt.Println("[slack: Xh]: Float time for non-critical activities (hours)")
+	fmt.Println("[slack: Xh, free: Yh]: Total and free float for non-critical activities (hours)")
+	fmt.Println("[late: Xh]: Negative float (constraint or deadline missed)")
 	fmt.Println("--------------------------------")
 	fmt.Println("   Activity and Material Tree:")
 	fmt.Println("--------------------------------")
 	prettyPrintRecursive(activities, "", false, criticalSet, slackMap)
 }
+
+// PrintFloatTable prints a table with the CPM dates and floats (hours) of the activities, in tree order.
+// Use a.GetActivities() for the rows and CalculateSlack (or Project.CalculateSlack) for slackMap.
+func PrintFloatTable(activities []*Activity, slackMap map[*Activity]SlackInfo) {
+	fmt.Println("--------------------------------")
+	fmt.Println("   Schedule Float (hours)")
+	fmt.Println("--------------------------------")
+	fmt.Printf("%-24s %8s %8s %8s %8s %8s %8s %8s %8s\n", "Activity", "ES", "EF", "LS", "LF", "Total", "Free", "Indep", "Interf")
+	fmt.Println(strings.Repeat("-", 24+9*8))
+	for _, act := range activities {
+		info, ok := slackMap[act]
+		if !ok {
+			continue
+		}
+		name := act.Name
+		if len(name) > 24 {
+			name = name[:21] + "..."
+		}
+		fmt.Printf("%-24s %8.0f %8.0f %8.0f %8.0f %8.0f %8.0f %8.0f %8.0f\n", name,
+			info.ES, info.EF, info.LS, info.LF, info.TotalFloat, info.FreeFloat, info.IndependentFloat, info.InterferingFloat)
+	}
+}
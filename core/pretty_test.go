@@ -68,3 +68,40 @@ func TestPrettyPrint_NilCriticalPath(t *testing.T) {
 		t.Error("PrettyPrint with nil criticalPath should still print activity")
 	}
 }
+
+func TestPrintFloatTable(t *testing.T) {
+	root := NewActivity("Root", "", *unit.NewDuration(0, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	long := NewActivity("Long", "", *unit.NewDuration(3, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	short := NewActivity("Short", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	root.AddActivity(long)
+	root.AddActivity(short)
+
+	old := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Pipe: %v", err)
+	}
+	os.Stdout = w
+
+	PrintFloatTable(root.GetActivities(), root.CalculateSlack())
+
+	os.Stdout = old
+	w.Close()
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	out := buf.String()
+
+	if !strings.Contains(out, "Interf") {
+		t.Error("PrintFloatTable output should contain the column headers")
+	}
+	var shortRow string
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "Short") {
+			shortRow = line
+		}
+	}
+	if got := strings.Fields(shortRow); len(got) != 9 || got[5] != "48" || got[6] != "48" {
+		t.Errorf("Short row = %q, want total and free float 48", shortRow)
+	}
+}
@@ -1,7 +1,11 @@
 package core
 
+import "explosio/core/unit"
+
 // Pricer is implemented by any type that can calculate its price.
-// Activity, Asset, HumanResource, ComplexMaterial, CountableMaterial, and MeasurableMaterial implement this interface.
+// Activity, Asset, HumanResource, resource.Assignment, ComplexMaterial, CountableMaterial, and MeasurableMaterial implement this interface.
+// CalculateMoney returns the exact amount; CalculatePrice is the same amount as a float.
 type Pricer interface {
 	CalculatePrice() float64
+	CalculateMoney() unit.Money
 }
@@ -0,0 +1,214 @@
+package core
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"explosio/core/unit"
+)
+
+// Cost categories of the pricing policy, as in CostBreakdown.
+const (
+	CategoryActivities = "activities"
+	CategoryMaterials  = "materials"
+	CategoryHuman      = "human"
+	CategoryAssets     = "assets"
+)
+
+// categoryNames lists the cost categories in CostBreakdown order.
+var categoryNames = []string{CategoryActivities, CategoryMaterials, CategoryHuman, CategoryAssets}
+
+// String returns the policy name of the category (e.g. "materials").
+func (c costCategory) String() string {
+	return categoryNames[c]
+}
+
+// IsValidCostCategory returns true if c is a known cost category.
+func IsValidCostCategory(c string) bool {
+	for _, name := range categoryNames {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+// PriceRule is a percentage applied to the priced items it matches. Empty criteria match any item:
+// a rule with no criteria is the default. Item matches the name of a material, resource or activity,
+// Supplier the supplier of a material (case-insensitive).
+type PriceRule struct {
+	Category string  `json:"category,omitempty" yaml:"category,omitempty"`
+	Item     string  `json:"item,omitempty" yaml:"item,omitempty"`
+	Supplier string  `json:"supplier,omitempty" yaml:"supplier,omitempty"`
+	Percent  float64 `json:"percent" yaml:"percent"`
+}
+
+// matches returns true if the rule applies to the item.
+func (r PriceRule) matches(it pricedItem) bool {
+	return (r.Category == "" || r.Category == it.category.String()) &&
+		(r.Item == "" || strings.EqualFold(r.Item, it.name)) &&
+		(r.Supplier == "" || strings.EqualFold(r.Supplier, it.supplier))
+}
+
+// specificity ranks matching rules: an item rule beats a supplier rule, which beats a category rule.
+func (r PriceRule) specificity() int {
+	s := 0
+	if r.Item != "" {
+		s += 4
+	}
+	if r.Supplier != "" {
+		s += 2
+	}
+	if r.Category != "" {
+		s++
+	}
+	return s
+}
+
+// PricingPolicy turns the cost of the tree into a quote: for every priced item, the discount is deducted from
+// the cost (net), the markup is added to the net, and the tax is charged on net plus markup (gross).
+// For each item the most specific matching rule of each list applies (the first one on a tie).
+type PricingPolicy struct {
+	Discounts []PriceRule `json:"discounts,omitempty" yaml:"discounts,omitempty"` // Negotiated discounts (e.g. per supplier)
+	Markups   []PriceRule `json:"markups,omitempty" yaml:"markups,omitempty"`     // Contractor markup (e.g. on materials)
+	Taxes     []PriceRule `json:"taxes,omitempty" yaml:"taxes,omitempty"`         // Tax rates such as VAT, per category or item
+}
+
+// percent returns the percentage of the most specific rule matching the item (0 if none).
+func percent(rules []PriceRule, it pricedItem) float64 {
+	best := -1
+	var p float64
+	for _, r := range rules {
+		if r.matches(it) && r.specificity() > best {
+			best = r.specificity()
+			p = r.Percent
+		}
+	}
+	return p
+}
+
+// validate reports unknown categories and out-of-range percentages.
+func (p *PricingPolicy) validate(r *ValidationResult, activity string) {
+	check := func(kind string, rules []PriceRule, max float64) {
+		for _, rule := range rules {
+			if rule.Category != "" && !IsValidCostCategory(rule.Category) {
+				r.AddError(activity, fmt.Sprintf("%s rule: unknown category %q (use %s)", kind, rule.Category, strings.Join(categoryNames, ", ")))
+			}
+			if rule.Percent < 0 || (max > 0 && rule.Percent > max) {
+				r.AddError(activity, fmt.Sprintf("%s rule: invalid percent %g", kind, rule.Percent))
+			}
+		}
+	}
+	check("discount", p.Discounts, 100)
+	check("markup", p.Markups, 0)
+	check("tax", p.Taxes, 0)
+}
+
+// QuoteLine holds the figures of a cost category (or the total) after the pricing policy.
+type QuoteLine struct {
+	Category string
+	Cost     unit.Money // Cost before the policy (as in CostBreakdown)
+	Discount unit.Money
+	Net      unit.Money // Cost - Discount
+	Markup   unit.Money
+	Tax      unit.Money
+	Gross    unit.Money // Net + Markup + Tax
+}
+
+// add adds the figures of o to the line.
+func (l *QuoteLine) add(o QuoteLine) {
+	l.Cost = l.Cost.Add(o.Cost)
+	l.Discount = l.Discount.Add(o.Discount)
+	l.Net = l.Net.Add(o.Net)
+	l.Markup = l.Markup.Add(o.Markup)
+	l.Tax = l.Tax.Add(o.Tax)
+	l.Gross = l.Gross.Add(o.Gross)
+}
+
+// Quote is the cost of the tree with the pricing policy applied, per category and in total, in one currency.
+type Quote struct {
+	Currency   string
+	Categories []QuoteLine // One line per category, in CostBreakdown order
+	Total      QuoteLine
+}
+
+// Quote applies the policy to every priced item of the tree, converted into the reporting currency.
+// Amounts are exact to 4 decimals (half-even); it fails if a currency has no exchange rate on the conversion date.
+func (a *Activity) Quote(policy PricingPolicy, c CurrencyConversion) (*Quote, error) {
+	q := &Quote{Currency: c.Currency, Total: QuoteLine{Category: "total"}}
+	for _, name := range categoryNames {
+		q.Categories = append(q.Categories, QuoteLine{Category: name})
+	}
+	for _, act := range a.GetActivities() {
+		for _, item := range act.ownPricedItems() {
+			if item.value.IsZero() {
+				continue
+			}
+			r, err := c.Rates.Rate(item.currency, c.Currency, c.Date)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", act.Name, err)
+			}
+			line := QuoteLine{Cost: item.value.Mul(r, unit.RoundHalfEven)}
+			line.Cost.Currency = c.Currency
+			line.Discount = line.Cost.Mul(percent(policy.Discounts, item)/100, unit.RoundHalfEven)
+			line.Net = line.Cost.Sub(line.Discount)
+			line.Markup = line.Net.Mul(percent(policy.Markups, item)/100, unit.RoundHalfEven)
+			line.Tax = line.Net.Add(line.Markup).Mul(percent(policy.Taxes, item)/100, unit.RoundHalfEven)
+			line.Gross = line.Net.Add(line.Markup).Add(line.Tax)
+			q.Categories[item.category].add(line)
+			q.Total.add(line)
+		}
+	}
+	return q, nil
+}
+
+// Quote applies the project pricing policy to the tree, in currency (the reporting currency if empty)
+// with the exchange rates effective on the date. Without a policy, net and gross equal the cost.
+func (p *Project) Quote(currency string, on unit.Date) (*Quote, error) {
+	var policy PricingPolicy
+	if p.Pricing != nil {
+		policy = *p.Pricing
+	}
+	return p.Root.Quote(policy, p.CurrencyConversion(currency, on))
+}
+
+// PrintQuote prints the quote table: cost, discount, net, markup, tax and gross per category,
+// rounded half-even to the currency minor unit.
+func PrintQuote(q *Quote) {
+	fmt.Println("--------------------------------")
+	fmt.Printf("   Quote (%s)\n", q.Currency)
+	fmt.Println("--------------------------------")
+	fmt.Printf("%-10s %12s %12s %12s %12s %12s %12s\n", "Category", "Cost", "Discount", "Net", "Markup", "Tax", "Gross")
+	fmt.Println(strings.Repeat("-", 10+13*6))
+	minor := unit.MinorUnits(q.Currency)
+	row := func(l QuoteLine) {
+		fmt.Printf("%-10s %12s %12s %12s %12s %12s %12s\n", l.Category, l.Cost.Decimal(minor), l.Discount.Decimal(minor),
+			l.Net.Decimal(minor), l.Markup.Decimal(minor), l.Tax.Decimal(minor), l.Gross.Decimal(minor))
+	}
+	for _, l := range q.Categories {
+		row(l)
+	}
+	fmt.Println(strings.Repeat("-", 10+13*6))
+	row(q.Total)
+}
+
+// WriteQuoteCSV writes the quote as CSV, one row per category and a total row, rounded to the currency minor unit.
+func WriteQuoteCSV(out io.Writer, q *Quote) error {
+	cw := csv.NewWriter(out)
+	if err := cw.Write([]string{"category", "cost", "discount", "net", "markup", "tax", "gross", "currency"}); err != nil {
+		return err
+	}
+	minor := unit.MinorUnits(q.Currency)
+	lines := append(append([]QuoteLine(nil), q.Categories...), q.Total)
+	for _, l := range lines {
+		row := []string{l.Category, l.Cost.Decimal(minor), l.Discount.Decimal(minor), l.Net.Decimal(minor),
+			l.Markup.Decimal(minor), l.Tax.Decimal(minor), l.Gross.Decimal(minor), q.Currency}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
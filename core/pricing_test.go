@@ -0,0 +1,113 @@
+package core
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"explosio/core/material"
+	"explosio/core/unit"
+)
+
+// buildPricingProject returns a project with labor, tiles from a discounted supplier and grout,
+// with 22% VAT, 10% VAT on the electrician and a 15% markup on materials.
+func buildPricingProject() *Project {
+	root, plaster, tiling := buildLinkTestTree()
+	tiling.Price.Value = 100
+	tiles := material.NewCountableMaterial("Tiles", "", *unit.NewPrice(20, "EUR"), 10)
+	tiles.Supplier = "Acme"
+	tiling.AddCountableMaterial(tiles)
+	tiling.AddCountableMaterial(material.NewCountableMaterial("Grout", "", *unit.NewPrice(5, "EUR"), 2))
+	plaster.AddHumanResource(newElectrician())
+	proj := NewProject(root)
+	proj.Pricing = &PricingPolicy{
+		Discounts: []PriceRule{{Supplier: "acme", Percent: 10}},
+		Markups:   []PriceRule{{Category: CategoryMaterials, Percent: 15}},
+		Taxes:     []PriceRule{{Percent: 22}, {Item: "Electrician", Percent: 10}},
+	}
+	return proj
+}
+
+func TestProject_Quote(t *testing.T) {
+	q, err := buildPricingProject().Quote("", unit.Date{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		line                                    QuoteLine
+		cost, discount, net, markup, tax, gross string
+	}{
+		// Tiling price: no discount or markup, default VAT.
+		{q.Categories[0], "100.00", "0.00", "100.00", "0.00", "22.00", "122.00"},
+		// Tiles 200 - 10% = 180, +15% = 207, +22% = 252.54; grout 10, +15% = 11.5, +22% = 14.03.
+		{q.Categories[1], "210.00", "20.00", "190.00", "28.50", "48.07", "266.57"},
+		// The item rule beats the default tax.
+		{q.Categories[2], "200.00", "0.00", "200.00", "0.00", "20.00", "220.00"},
+		{q.Total, "510.00", "20.00", "490.00", "28.50", "90.07", "608.57"},
+	}
+	for _, tt := range tests {
+		l := tt.line
+		got := []string{l.Cost.Decimal(2), l.Discount.Decimal(2), l.Net.Decimal(2), l.Markup.Decimal(2), l.Tax.Decimal(2), l.Gross.Decimal(2)}
+		want := []string{tt.cost, tt.discount, tt.net, tt.markup, tt.tax, tt.gross}
+		if strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("%s = %v, want %v", l.Category, got, want)
+		}
+	}
+	if q.Currency != "EUR" || q.Total.Gross.Currency != "EUR" {
+		t.Errorf("currency = %q, gross %v, want EUR", q.Currency, q.Total.Gross)
+	}
+}
+
+func TestProject_QuoteWithoutPolicy(t *testing.T) {
+	proj := buildPricingProject()
+	proj.Pricing = nil
+	q, err := proj.Quote("", unit.Date{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Total.Gross != q.Total.Cost || q.Total.Cost.Float64() != proj.Root.CalculatePrice() {
+		t.Errorf("total = %+v, want gross = cost = %v", q.Total, proj.Root.CalculatePrice())
+	}
+}
+
+func TestPricingPolicy_Validate(t *testing.T) {
+	proj := buildPricingProject()
+	proj.Pricing.Markups = append(proj.Pricing.Markups, PriceRule{Category: "labour", Percent: 5})
+	proj.Pricing.Discounts = append(proj.Pricing.Discounts, PriceRule{Item: "Grout", Percent: 120})
+	if r := proj.Validate(); len(r.Errors) != 2 {
+		t.Errorf("errors = %v, want unknown category and invalid percent", r.Errors)
+	}
+}
+
+func TestWriteQuoteCSV(t *testing.T) {
+	q, err := buildPricingProject().Quote("", unit.Date{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteQuoteCSV(&buf, q); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 6 || lines[5] != "total,510.00,20.00,490.00,28.50,90.07,608.57,EUR" {
+		t.Errorf("CSV = %q", buf.String())
+	}
+}
+
+func TestPricingPolicy_YAMLRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := buildPricingProject().WriteYAML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadYAML(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := read.Quote("", unit.Date{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := q.Total.Gross.Decimal(2); got != "608.57" {
+		t.Errorf("gross after round-trip = %s, want 608.57 (policy and supplier saved)", got)
+	}
+}
@@ -0,0 +1,99 @@
+package core
+
+import "explosio/core/unit"
+
+// IsStarted returns true if work on the activity has begun (actual start or percent complete set).
+func (a *Activity) IsStarted() bool {
+	return a.ActualStart != nil || a.PercentComplete > 0 || a.IsComplete()
+}
+
+// IsComplete returns true if the activity is finished (actual finish set or 100% complete).
+func (a *Activity) IsComplete() bool {
+	return a.ActualFinish != nil || a.PercentComplete >= 100
+}
+
+// completedFraction returns the completed share of the activity (0 to 1).
+func (a *Activity) completedFraction() float64 {
+	switch {
+	case a.IsComplete():
+		return 1
+	case a.PercentComplete <= 0:
+		return 0
+	}
+	return a.PercentComplete / 100
+}
+
+// hasProgress returns true if any activity in the tree has progress recorded.
+func (a *Activity) hasProgress() bool {
+	if a.IsStarted() {
+		return true
+	}
+	for _, child := range a.Activities {
+		if child.hasProgress() {
+			return true
+		}
+	}
+	return false
+}
+
+// tracksProgress returns true if the schedule is updated with progress: it needs a project start and a status date.
+func (o scheduleOptions) tracksProgress() bool {
+	return o.start != nil && o.status != nil
+}
+
+// statusHours returns the status date in CPM hours: progress is recorded up to the end of that date.
+func (o scheduleOptions) statusHours() float64 {
+	return o.hoursAtEndOf(*o.status)
+}
+
+// remainingHours returns the work left on a started activity: RemainingDuration if set, else the share of
+// the planned duration not yet complete.
+func (o scheduleOptions) remainingHours(a *Activity) float64 {
+	if a.IsComplete() {
+		return 0
+	}
+	if a.RemainingDuration != nil {
+		return o.hours(*a.RemainingDuration)
+	}
+	return o.activityHours(a) * (1 - a.completedFraction())
+}
+
+// progressStart returns the early start of the activity given progress: the actual start if recorded;
+// for work not started, no earlier than the status date, since it cannot happen in the past.
+func (o scheduleOptions) progressStart(a *Activity, es float64) float64 {
+	if a.ActualStart != nil {
+		return o.hoursAt(*a.ActualStart)
+	}
+	if !a.IsStarted() {
+		if s := o.statusHours(); s > es {
+			return s
+		}
+	}
+	return es
+}
+
+// progressFinish returns the early finish of the activity starting at es given progress: the end of the actual
+// finish date for completed work, the status date plus the remaining work for work in progress.
+func (o scheduleOptions) progressFinish(a *Activity, es float64) float64 {
+	switch {
+	case a.ActualFinish != nil:
+		if f := o.hoursAtEndOf(*a.ActualFinish); f > es {
+			return f
+		}
+		return es
+	case a.IsComplete(), !a.IsStarted():
+		return es + o.activityHours(a)
+	}
+	from := o.statusHours()
+	if es > from {
+		from = es
+	}
+	return from + o.remainingHours(a)
+}
+
+// SetProgress records the percent complete (0-100) and the actual start; actualFinish may be nil for work in progress.
+func (a *Activity) SetProgress(percent float64, actualStart, actualFinish *unit.Date) {
+	a.PercentComplete = percent
+	a.ActualStart = actualStart
+	a.ActualFinish = actualFinish
+}
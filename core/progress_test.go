@@ -0,0 +1,134 @@
+package core
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"explosio/core/unit"
+)
+
+// newProgressProject returns Plaster (4d) followed by Tiling (3d), starting on 2026-03-02 with continuous time.
+func newProgressProject() (*Project, *Activity, *Activity) {
+	root, plaster, tiling := buildLinkTestTree()
+	tiling.AddDependsOn(plaster)
+	proj := NewProject(root)
+	start := unit.NewDate(2026, time.March, 2)
+	proj.Start = &start
+	return proj, plaster, tiling
+}
+
+func date(day int) *unit.Date {
+	d := unit.NewDate(2026, time.March, day)
+	return &d
+}
+
+func TestProgress_RemainingWorkFromStatusDate(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int // Day of March
+		percent    float64
+		remaining  *unit.Duration
+		wantEF     float64 // Plaster early finish
+		wantTiling float64 // Tiling early start
+	}{
+		{"on schedule", 3, 50, nil, 96, 96},
+		{"behind schedule", 4, 50, nil, 120, 120},
+		{"remaining overrides percent", 3, 50, unit.NewDuration(1, unit.DurationUnitDay), 72, 72},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proj, plaster, tiling := newProgressProject()
+			proj.StatusDate = date(tt.status)
+			plaster.SetProgress(tt.percent, date(2), nil)
+			plaster.RemainingDuration = tt.remaining
+
+			m := proj.CalculateSlack()
+			if m[plaster].ES != 0 || m[plaster].EF != tt.wantEF {
+				t.Errorf("Plaster = %v-%v, want 0-%v", m[plaster].ES, m[plaster].EF, tt.wantEF)
+			}
+			if m[tiling].ES != tt.wantTiling {
+				t.Errorf("Tiling ES = %v, want %v", m[tiling].ES, tt.wantTiling)
+			}
+		})
+	}
+}
+
+func TestProgress_CompletedAndNotStarted(t *testing.T) {
+	proj, plaster, tiling := newProgressProject()
+	proj.StatusDate = date(5)
+	plaster.SetProgress(100, date(2), date(3)) // finished early, at the end of March 3
+
+	m := proj.CalculateSlack()
+	if m[plaster].EF != 48 {
+		t.Errorf("Plaster EF = %v, want 48 (end of actual finish)", m[plaster].EF)
+	}
+	// Tiling could start after Plaster but not before the status date (end of March 5).
+	if m[tiling].ES != 96 || m[tiling].EF != 168 {
+		t.Errorf("Tiling = %v-%v, want 96-168", m[tiling].ES, m[tiling].EF)
+	}
+
+	// Without a status date progress is ignored.
+	proj.StatusDate = nil
+	if m := proj.CalculateSlack(); m[tiling].ES != 96 || m[plaster].EF != 96 {
+		t.Errorf("without status date: Plaster EF %v, Tiling ES %v, want 96 and 96", m[plaster].EF, m[tiling].ES)
+	}
+	if r := proj.Validate(); len(r.Warnings) == 0 {
+		t.Error("expected a warning about progress ignored without a status date")
+	}
+}
+
+func TestProgress_Validation(t *testing.T) {
+	proj, plaster, tiling := newProgressProject()
+	plaster.SetProgress(120, date(5), date(3))
+	tiling.ActualFinish = date(6)
+	r := proj.Validate()
+	if len(r.Errors) != 3 {
+		t.Errorf("errors = %v, want percent, finish before start and finish without start", r.Errors)
+	}
+}
+
+func TestProgress_RoundTripAndGantt(t *testing.T) {
+	proj, plaster, _ := newProgressProject()
+	proj.StatusDate = date(3)
+	plaster.SetProgress(50, date(2), nil)
+
+	var buf bytes.Buffer
+	if err := proj.WriteYAML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ReadYAML(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.StatusDate == nil || loaded.StatusDate.String() != "2026-03-03" {
+		t.Errorf("status date = %v, want 2026-03-03", loaded.StatusDate)
+	}
+	lp := loaded.Root.Activities[0]
+	if lp.PercentComplete != 50 || lp.ActualStart == nil || lp.ActualStart.String() != "2026-03-02" {
+		t.Errorf("loaded progress = %v %v, want 50%% from 2026-03-02", lp.PercentComplete, lp.ActualStart)
+	}
+
+	old := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Pipe: %v", err)
+	}
+	os.Stdout = w
+	loaded.PrintGantt(GanttConfig{ProjectStart: *loaded.Start, Width: 14, ShowDates: true})
+	os.Stdout = old
+	w.Close()
+	var out bytes.Buffer
+	io.Copy(&out, r)
+
+	// Plaster fills 8 of 14 cells (96 of 168 hours), half of them completed.
+	if !strings.Contains(out.String(), "|▓▓▓▓████░░░░░░|") {
+		t.Errorf("Gantt should draw completed work as ▓, got:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "Status date: 2026-03-03") {
+		t.Error("Gantt should print the status date")
+	}
+}
@@ -0,0 +1,165 @@
+package core
+
+import (
+	"explosio/core/resource"
+	"explosio/core/unit"
+	"fmt"
+)
+
+// This file contains scheduling methods on Project: they use the project settings
+// (conversion policy, calendar, start date and deadline).
+
+// DurationConversion returns the project's duration conversion policy (unit.DefaultConversion if not set).
+func (p *Project) DurationConversion() unit.DurationConversion {
+	if p.Conversion != nil {
+		return *p.Conversion
+	}
+	return unit.DefaultConversion()
+}
+
+// scheduleOptions returns the CPM options for this project.
+func (p *Project) scheduleOptions() scheduleOptions {
+	return scheduleOptions{
+		conv:     p.DurationConversion(),
+		cal:      p.Calendar,
+		start:    p.Start,
+		deadline: p.Deadline,
+		status:   p.StatusDate,
+	}
+}
+
+// CalculateSlack returns the slack map of the project tree using the project conversion policy.
+func (p *Project) CalculateSlack() map[*Activity]SlackInfo {
+	m, _ := p.Root.calculateSlack(p.scheduleOptions())
+	return m
+}
+
+// CalculateCriticalPath returns the critical path of the project tree using the project conversion policy.
+func (p *Project) CalculateCriticalPath() []*Activity {
+	return p.Root.calculateCriticalPath(p.scheduleOptions(), CriticalPathZeroFloat)
+}
+
+// CalculateCriticalPathWithMode returns the critical path selected by mode using the project settings.
+func (p *Project) CalculateCriticalPathWithMode(mode CriticalPathMode) []*Activity {
+	return p.Root.calculateCriticalPath(p.scheduleOptions(), mode)
+}
+
+// ComputeSchedule computes start/end dates for all activities using the project conversion policy, calendar and deadline.
+func (p *Project) ComputeSchedule(projectStart unit.Date) map[*Activity]Schedule {
+	return p.Root.computeSchedule(projectStart, p.scheduleOptions())
+}
+
+// PrintGantt prints the Gantt chart of the project. Calendar, Conversion, Deadline and StatusDate default to the project settings.
+func (p *Project) PrintGantt(cfg GanttConfig) {
+	if cfg.Calendar == nil {
+		cfg.Calendar = p.Calendar
+	}
+	if cfg.Conversion == nil {
+		conv := p.DurationConversion()
+		cfg.Conversion = &conv
+	}
+	if cfg.Deadline == nil {
+		cfg.Deadline = p.Deadline
+	}
+	if cfg.StatusDate == nil {
+		cfg.StatusDate = p.StatusDate
+	}
+	p.Root.PrintGantt(cfg)
+}
+
+// Validate checks the activity tree (see Activity.Validate), the resource pool and the schedule: activities with
+// negative float, a project end after the deadline and over-allocated resources are reported as warnings.
+func (p *Project) Validate() *ValidationResult {
+	r := p.Root.validateTree()
+	p.validateCurrencies(r)
+	if p.Pricing != nil {
+		p.Pricing.validate(r, p.Root.Name)
+	}
+	p.validatePool(r)
+	if !r.Valid() {
+		return r
+	}
+	opts := p.scheduleOptions()
+	if opts.start == nil {
+		if p.Deadline != nil {
+			r.AddWarning(p.Root.Name, "deadline is ignored: the project has no start date")
+		}
+		for _, act := range allActivities(p.Root) {
+			if act.Constraint != nil && act.Constraint.Type.needsDate() {
+				r.AddWarning(act.Name, fmt.Sprintf("constraint %s is ignored: the project has no start date", act.Constraint.String()))
+			}
+		}
+	}
+	if p.Root.hasProgress() && !opts.tracksProgress() {
+		r.AddWarning(p.Root.Name, "progress is ignored: the project needs a start and a status date")
+	}
+	if p.StatusDate != nil {
+		for _, act := range allActivities(p.Root) {
+			if act.ActualStart != nil && act.ActualStart.Time.After(p.StatusDate.Time) {
+				r.AddWarning(act.Name, fmt.Sprintf("actual start %s is after the status date %s", act.ActualStart, p.StatusDate))
+			}
+			if act.ActualFinish != nil && act.ActualFinish.Time.After(p.StatusDate.Time) {
+				r.AddWarning(act.Name, fmt.Sprintf("actual finish %s is after the status date %s", act.ActualFinish, p.StatusDate))
+			}
+		}
+	}
+
+	m, projectEnd := p.Root.calculateSlack(opts)
+	if d, ok := opts.deadlineHours(); ok && projectEnd > d+slackEpsilon {
+		r.AddWarning(p.Root.Name, fmt.Sprintf("project finishes %.0f hours after the deadline %s", projectEnd-d, p.Deadline.String()))
+	}
+	for _, act := range allActivities(p.Root) {
+		if info, ok := m[act]; ok && info.TotalFloat < -slackEpsilon {
+			r.AddWarning(act.Name, fmt.Sprintf("negative float: %.0f hours late", -info.TotalFloat))
+		}
+	}
+	for _, o := range p.OverAllocations() {
+		r.AddWarning(p.Root.Name, o.describe(p))
+	}
+	return r
+}
+
+// validateCurrencies checks the exchange rates and, if the project has any, that every currency used can be
+// converted into the reporting currency with the rates effective on the conversion date (see ConversionDate).
+// Without exchange rates it warns about mixed currencies, as Activity.Validate does.
+func (p *Project) validateCurrencies(r *ValidationResult) {
+	if len(p.ExchangeRates) == 0 {
+		p.Root.checkCurrencies(r)
+		return
+	}
+	for _, rate := range p.ExchangeRates {
+		if rate.Rate <= 0 || rate.From == "" || rate.To == "" {
+			r.AddError(p.Root.Name, fmt.Sprintf("invalid exchange rate %s -> %s: %g", rate.From, rate.To, rate.Rate))
+		}
+	}
+	on := p.ConversionDate()
+	to := p.ReportingCurrency()
+	for _, c := range p.Root.currencies() {
+		if _, err := p.ExchangeRates.Rate(c, to, on); err != nil {
+			r.AddWarning(p.Root.Name, err.Error())
+		}
+	}
+}
+
+// validatePool checks that pool resource IDs are unique and that every assignment references a pool resource.
+func (p *Project) validatePool(r *ValidationResult) {
+	inPool := make(map[*resource.Resource]bool)
+	ids := make(map[string]bool)
+	for _, res := range p.Resources {
+		if res.ID != "" && ids[res.ID] {
+			r.AddError(p.Root.Name, fmt.Sprintf("duplicate resource ID %q (%s)", res.ID, res.Name))
+		}
+		ids[res.ID] = true
+		inPool[res] = true
+		if res.MaxUnits < 0 {
+			r.AddError(p.Root.Name, fmt.Sprintf("resource %q has negative max units", res.Name))
+		}
+	}
+	for _, act := range allActivities(p.Root) {
+		for _, as := range act.Assignments {
+			if as.Resource != nil && !inPool[as.Resource] {
+				r.AddError(act.Name, fmt.Sprintf("assigned resource %q is not in the project resource pool", as.Resource.Name))
+			}
+		}
+	}
+}
@@ -9,23 +9,29 @@ import (
 	"explosio/core/material"
 	"explosio/core/resource/asset"
 	"explosio/core/resource/human"
+	"explosio/core/unit"
 )
 
 // FilterOptions holds criteria for filtering activities.
 type FilterOptions struct {
-	PriceMin    float64 // Minimum price (0 = no filter)
-	PriceMax    float64 // Maximum price (0 = no filter)
-	DurationMin float64 // Minimum duration in hours (0 = no filter)
-	DurationMax float64 // Maximum duration in hours (0 = no filter)
-	Name        string  // Substring match on activity name (empty = no filter)
-	NameRegex   string  // Regex match on activity name (empty = no filter)
-	MaterialName string // Filter activities that use a material with this name (substring)
-	ResourceName string // Filter activities that use a human resource with this name (substring)
+	PriceMin     float64                  // Minimum price (0 = no filter)
+	PriceMax     float64                  // Maximum price (0 = no filter)
+	DurationMin  float64                  // Minimum duration in hours (0 = no filter)
+	DurationMax  float64                  // Maximum duration in hours (0 = no filter)
+	Name         string                   // Substring match on activity name (empty = no filter)
+	NameRegex    string                   // Regex match on activity name (empty = no filter)
+	MaterialName string                   // Filter activities that use a material with this name (substring)
+	ResourceName string                   // Filter activities that use a human resource with this name (substring)
+	Conversion   *unit.DurationConversion // Conversion for DurationMin/DurationMax (nil = unit.DefaultConversion)
 }
 
 // FilterActivities returns activities that match the given filter options.
 func FilterActivities(activities []*Activity, opts FilterOptions) []*Activity {
 	var result []*Activity
+	conv := unit.DefaultConversion()
+	if opts.Conversion != nil {
+		conv = *opts.Conversion
+	}
 	var nameRe *regexp.Regexp
 	if opts.NameRegex != "" {
 		nameRe, _ = regexp.Compile(opts.NameRegex)
@@ -37,7 +43,7 @@ func FilterActivities(activities []*Activity, opts FilterOptions) []*Activity {
 		if opts.PriceMax > 0 && a.CalculatePrice() > opts.PriceMax {
 			continue
 		}
-		durHours := a.calculateDurationHours()
+		durHours := a.calculateDurationHours(conv)
 		if opts.DurationMin > 0 && durHours < opts.DurationMin {
 			continue
 		}
@@ -126,6 +132,16 @@ func (a *Activity) GetActivities() []*Activity {
 	return activities
 }
 
+// FindByID returns the activity with the given ID in this subtree, or nil if not found.
+func (a *Activity) FindByID(id string) *Activity {
+	for _, act := range a.GetActivities() {
+		if act.ID == id {
+			return act
+		}
+	}
+	return nil
+}
+
 func (a *Activity) GetComplexMaterials() []*material.ComplexMaterial {
 	complexMaterials := a.ComplexMaterials
 	for _, activity := range a.Activities {
@@ -2,17 +2,21 @@ package asset
 
 import (
 	"errors"
+	"explosio/core/resource"
 	"explosio/core/unit"
 )
 
 // AssetBuilder builds an asset.
 type AssetBuilder struct {
-	asset *Asset
+	asset   *Asset
+	conv    unit.DurationConversion
+	rate    *unit.Price // Rate set by WithHourlyRate/WithDailyRate, turned into the total price by Build
+	ratePer unit.DurationUnit
 }
 
 // NewAssetBuilder creates a new asset builder with empty name and description, zero price and duration.
 func NewAssetBuilder() *AssetBuilder {
-	return &AssetBuilder{asset: NewAsset("", "", *unit.NewPrice(0, "EUR"), *unit.NewDuration(0, unit.DurationUnitHour))}
+	return &AssetBuilder{asset: NewAsset("", "", *unit.NewPrice(0, "EUR"), *unit.NewDuration(0, unit.DurationUnitHour)), conv: resource.DefaultRateConversion()}
 }
 
 // WithName sets the name and returns the builder for chaining.
@@ -21,6 +25,12 @@ func (b *AssetBuilder) WithName(name string) *AssetBuilder {
 	return b
 }
 
+// WithCode sets the catalog code and returns the builder for chaining.
+func (b *AssetBuilder) WithCode(code string) *AssetBuilder {
+	b.asset.Code = code
+	return b
+}
+
 // WithDescription sets the description and returns the builder for chaining.
 func (b *AssetBuilder) WithDescription(description string) *AssetBuilder {
 	b.asset.Description = description
@@ -30,6 +40,7 @@ func (b *AssetBuilder) WithDescription(description string) *AssetBuilder {
 // WithPrice sets the price and returns the builder for chaining.
 func (b *AssetBuilder) WithPrice(price unit.Price) *AssetBuilder {
 	b.asset.Price = price
+	b.rate = nil
 	return b
 }
 
@@ -42,25 +53,35 @@ func (b *AssetBuilder) WithDuration(duration unit.Duration) *AssetBuilder {
 // WithTotalPrice sets the total price. Hourly and daily rates are derived from Price/Duration.
 func (b *AssetBuilder) WithTotalPrice(totalPrice unit.Price) *AssetBuilder {
 	b.asset.SetTotalPrice(totalPrice)
+	b.rate = nil
 	return b
 }
 
-// WithHourlyRate sets the hourly rate and derives the total price from duration.
-// Duration must be set before calling this. If Duration.ToHours() is 0, total price becomes 0.
+// WithHourlyRate sets the hourly rate; Build derives the total price from the duration converted with the builder
+// policy (see WithConversion), so 1 day at 10 EUR/hour is 240 EUR by default and 80 EUR with 8-hour working days.
 func (b *AssetBuilder) WithHourlyRate(rate unit.Price) *AssetBuilder {
-	b.asset.SetHourlyRate(rate)
+	b.rate, b.ratePer = &rate, unit.DurationUnitHour
 	return b
 }
 
-// WithDailyRate sets the daily rate (per working day) and derives the total price from duration.
-// Duration must be set before calling this. If Duration.ToHours() is 0, total price becomes 0.
+// WithDailyRate sets the rate per day; Build derives the total price from the duration in days of the builder policy.
 func (b *AssetBuilder) WithDailyRate(rate unit.Price) *AssetBuilder {
-	b.asset.SetDailyRate(rate)
+	b.rate, b.ratePer = &rate, unit.DurationUnitDay
+	return b
+}
+
+// WithConversion sets the duration conversion used to price rates, normally the project policy
+// (Project.DurationConversion). Default: resource.DefaultRateConversion.
+func (b *AssetBuilder) WithConversion(conv unit.DurationConversion) *AssetBuilder {
+	b.conv = conv
 	return b
 }
 
 // Build builds the asset. Returns an error if name is empty, duration is negative, or price is invalid (negative value or empty currency).
 func (b *AssetBuilder) Build() (*Asset, error) {
+	if b.rate != nil {
+		b.asset.SetRateWith(*b.rate, b.ratePer, b.conv)
+	}
 	if b.asset.Name == "" {
 		return nil, errors.New("asset name cannot be empty")
 	}
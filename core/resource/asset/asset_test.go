@@ -36,9 +36,9 @@ func TestAsset_SetHourlyRate(t *testing.T) {
 
 func TestAsset_SetDailyRate(t *testing.T) {
 	t.Run("duration > 0 derives total price", func(t *testing.T) {
-		a := NewAsset("Tool", "", unit.Price{}, *unit.NewDuration(8, unit.DurationUnitHour))
+		a := NewAsset("Tool", "", unit.Price{}, *unit.NewDuration(24, unit.DurationUnitHour))
 		a.SetDailyRate(*unit.NewPrice(80, "EUR"))
-		// 8 hours = 1 working day, so Price = 80 * 8/8 = 80
+		// 24 hours = 1 day of the default calendar-time policy, so Price = 80
 		if a.Price.Value != 80 {
 			t.Errorf("SetDailyRate: Price = %v, want 80", a.Price.Value)
 		}
@@ -86,7 +86,7 @@ func TestAssetBuilder_WithHourlyRate(t *testing.T) {
 func TestAssetBuilder_WithDailyRate(t *testing.T) {
 	a, err := NewAssetBuilder().
 		WithName("Tool").
-		WithDuration(*unit.NewDuration(8, unit.DurationUnitHour)).
+		WithDuration(*unit.NewDuration(24, unit.DurationUnitHour)).
 		WithDailyRate(*unit.NewPrice(80, "EUR")).
 		Build()
 	if err != nil {
@@ -96,3 +96,19 @@ func TestAssetBuilder_WithDailyRate(t *testing.T) {
 		t.Errorf("WithDailyRate: Price = %v, want 80", a.Price.Value)
 	}
 }
+
+func TestAssetBuilder_WithConversion(t *testing.T) {
+	// The rate can be set before the duration; the project policy decides how long a day is.
+	a, err := NewAssetBuilder().
+		WithName("Tool").
+		WithHourlyRate(*unit.NewPrice(10, "EUR")).
+		WithConversion(unit.WorkingTimeConversion(8, 5)).
+		WithDuration(*unit.NewDuration(1, unit.DurationUnitDay)).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if a.Price.Value != 80 {
+		t.Errorf("WithConversion(working): Price = %v, want 80 (8h * 10)", a.Price.Value)
+	}
+}
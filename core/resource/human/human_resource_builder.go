@@ -2,17 +2,21 @@ package human
 
 import (
 	"errors"
+	"explosio/core/resource"
 	"explosio/core/unit"
 )
 
 // HumanResourceBuilder builds a human resource.
 type HumanResourceBuilder struct {
 	humanResource *HumanResource
+	conv          unit.DurationConversion
+	rate          *unit.Price // Rate set by WithHourlyRate/WithDailyRate, turned into the total price by Build
+	ratePer       unit.DurationUnit
 }
 
 // NewHumanResourceBuilder creates a new human resource builder.
 func NewHumanResourceBuilder() *HumanResourceBuilder {
-	return &HumanResourceBuilder{humanResource: NewHumanResource("", "", *unit.NewDuration(0, unit.DurationUnitHour), *unit.NewPrice(0, "EUR"))}
+	return &HumanResourceBuilder{humanResource: NewHumanResource("", "", *unit.NewDuration(0, unit.DurationUnitHour), *unit.NewPrice(0, "EUR")), conv: resource.DefaultRateConversion()}
 }
 
 // WithName sets the name and returns the builder for chaining.
@@ -21,6 +25,12 @@ func (b *HumanResourceBuilder) WithName(name string) *HumanResourceBuilder {
 	return b
 }
 
+// WithCode sets the catalog code and returns the builder for chaining.
+func (b *HumanResourceBuilder) WithCode(code string) *HumanResourceBuilder {
+	b.humanResource.Code = code
+	return b
+}
+
 // WithDescription sets the description and returns the builder for chaining.
 func (b *HumanResourceBuilder) WithDescription(description string) *HumanResourceBuilder {
 	b.humanResource.Description = description
@@ -36,31 +46,42 @@ func (b *HumanResourceBuilder) WithDuration(duration unit.Duration) *HumanResour
 // WithPrice sets the price and returns the builder for chaining.
 func (b *HumanResourceBuilder) WithPrice(price unit.Price) *HumanResourceBuilder {
 	b.humanResource.Price = price
+	b.rate = nil
 	return b
 }
 
 // WithTotalPrice sets the total price. Hourly and daily rates are derived from Price/Duration.
 func (b *HumanResourceBuilder) WithTotalPrice(totalPrice unit.Price) *HumanResourceBuilder {
 	b.humanResource.SetTotalPrice(totalPrice)
+	b.rate = nil
 	return b
 }
 
-// WithHourlyRate sets the hourly rate and derives the total price from duration.
-// Duration must be set before calling this. If Duration.ToHours() is 0, total price becomes 0.
+// WithHourlyRate sets the hourly rate; Build derives the total price from the duration converted with the builder
+// policy (see WithConversion), so 1 day at 10 EUR/hour is 240 EUR by default and 80 EUR with 8-hour working days.
 func (b *HumanResourceBuilder) WithHourlyRate(rate unit.Price) *HumanResourceBuilder {
-	b.humanResource.SetHourlyRate(rate)
+	b.rate, b.ratePer = &rate, unit.DurationUnitHour
 	return b
 }
 
-// WithDailyRate sets the daily rate (per working day) and derives the total price from duration.
-// Duration must be set before calling this. If Duration.ToHours() is 0, total price becomes 0.
+// WithDailyRate sets the rate per day; Build derives the total price from the duration in days of the builder policy.
 func (b *HumanResourceBuilder) WithDailyRate(rate unit.Price) *HumanResourceBuilder {
-	b.humanResource.SetDailyRate(rate)
+	b.rate, b.ratePer = &rate, unit.DurationUnitDay
+	return b
+}
+
+// WithConversion sets the duration conversion used to price rates, normally the project policy
+// (Project.DurationConversion). Default: resource.DefaultRateConversion.
+func (b *HumanResourceBuilder) WithConversion(conv unit.DurationConversion) *HumanResourceBuilder {
+	b.conv = conv
 	return b
 }
 
 // Build builds the human resource. Returns an error if name is empty, duration is negative, or price is invalid (negative value or empty currency).
 func (b *HumanResourceBuilder) Build() (*HumanResource, error) {
+	if b.rate != nil {
+		b.humanResource.SetRateWith(*b.rate, b.ratePer, b.conv)
+	}
 	if b.humanResource.Name == "" {
 		return nil, errors.New("human resource name cannot be empty")
 	}
@@ -36,9 +36,9 @@ func TestHumanResource_SetHourlyRate(t *testing.T) {
 
 func TestHumanResource_SetDailyRate(t *testing.T) {
 	t.Run("duration > 0 derives total price", func(t *testing.T) {
-		h := NewHumanResource("Plumber", "", *unit.NewDuration(8, unit.DurationUnitHour), unit.Price{})
+		h := NewHumanResource("Plumber", "", *unit.NewDuration(24, unit.DurationUnitHour), unit.Price{})
 		h.SetDailyRate(*unit.NewPrice(80, "EUR"))
-		// 8 hours = 1 working day, so Price = 80 * 8/8 = 80
+		// 24 hours = 1 day of the default calendar-time policy, so Price = 80
 		if h.Price.Value != 80 {
 			t.Errorf("SetDailyRate: Price = %v, want 80", h.Price.Value)
 		}
@@ -86,7 +86,7 @@ func TestHumanResourceBuilder_WithHourlyRate(t *testing.T) {
 func TestHumanResourceBuilder_WithDailyRate(t *testing.T) {
 	h, err := NewHumanResourceBuilder().
 		WithName("Plumber").
-		WithDuration(*unit.NewDuration(8, unit.DurationUnitHour)).
+		WithDuration(*unit.NewDuration(24, unit.DurationUnitHour)).
 		WithDailyRate(*unit.NewPrice(80, "EUR")).
 		Build()
 	if err != nil {
@@ -96,3 +96,57 @@ func TestHumanResourceBuilder_WithDailyRate(t *testing.T) {
 		t.Errorf("WithDailyRate: Price = %v, want 80", h.Price.Value)
 	}
 }
+
+func TestHumanResourceBuilder_WithConversion(t *testing.T) {
+	// The rate can be set before the duration; the project policy decides how long a day is.
+	h, err := NewHumanResourceBuilder().
+		WithName("Plumber").
+		WithHourlyRate(*unit.NewPrice(10, "EUR")).
+		WithConversion(unit.WorkingTimeConversion(8, 5)).
+		WithDuration(*unit.NewDuration(1, unit.DurationUnitDay)).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if h.Price.Value != 80 {
+		t.Errorf("WithConversion(working): Price = %v, want 80 (8h * 10)", h.Price.Value)
+	}
+}
+
+func TestHumanResource_RatesWithConversion(t *testing.T) {
+	working := unit.WorkingTimeConversion(8, 5)
+	t.Run("daily rate counts working days", func(t *testing.T) {
+		h := NewHumanResource("Plumber", "", *unit.NewDuration(3, unit.DurationUnitDay), unit.Price{})
+		h.SetDailyRateWith(*unit.NewPrice(80, "EUR"), working)
+		if h.Price.Value != 240 {
+			t.Errorf("SetDailyRateWith: Price = %v, want 240 (3 days * 80)", h.Price.Value)
+		}
+		if got := h.CalculateDailyRateWith(working); got != 80 {
+			t.Errorf("CalculateDailyRateWith() = %v, want 80", got)
+		}
+	})
+	t.Run("hourly rate uses working hours", func(t *testing.T) {
+		h := NewHumanResource("Plumber", "", *unit.NewDuration(2, unit.DurationUnitDay), unit.Price{})
+		h.SetHourlyRateWith(*unit.NewPrice(10, "EUR"), working)
+		if h.Price.Value != 160 {
+			t.Errorf("SetHourlyRateWith: Price = %v, want 160 (16h * 10)", h.Price.Value)
+		}
+		if got := h.CalculateHourlyRateWith(working); got != 10 {
+			t.Errorf("CalculateHourlyRateWith() = %v, want 10", got)
+		}
+	})
+	t.Run("calendar time days agree with schedule", func(t *testing.T) {
+		h := NewHumanResource("Plumber", "", *unit.NewDuration(3, unit.DurationUnitDay), unit.Price{})
+		h.SetDailyRateWith(*unit.NewPrice(80, "EUR"), unit.CalendarTimeConversion())
+		if h.Price.Value != 240 {
+			t.Errorf("SetDailyRateWith(calendar): Price = %v, want 240", h.Price.Value)
+		}
+	})
+	t.Run("weekly rate counts working weeks", func(t *testing.T) {
+		h := NewHumanResource("Plumber", "", *unit.NewDuration(3, unit.DurationUnitDay), unit.Price{})
+		h.SetRateWith(*unit.NewPrice(1000, "EUR"), unit.DurationUnitWeek, working)
+		if h.Price.Value != 600 {
+			t.Errorf("SetRateWith(week): Price = %v, want 600 (24h of a 40h week)", h.Price.Value)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package resource
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"explosio/core/unit"
+)
+
+// Kind tells whether a pool resource is a person or a piece of equipment.
+type Kind string
+
+const (
+	KindHuman Kind = "human" // Person or role (default)
+	KindAsset Kind = "asset" // Equipment, tool, vehicle
+)
+
+// Resource is a shared resource of the project pool. Activities reference it through assignments,
+// so its rate is defined once and applies to every assignment.
+type Resource struct {
+	ID       string
+	Name     string
+	Role     string         `json:",omitempty" yaml:",omitempty"`
+	Code     string         `json:",omitempty" yaml:",omitempty"` // Catalog code: the rate is resolved from the catalog
+	Kind     Kind           `json:",omitempty" yaml:",omitempty"`
+	Rate     unit.Price     // Cost per hour of work
+	MaxUnits float64        `json:",omitempty" yaml:",omitempty"` // Units available at the same time (0 = 1)
+	Calendar *unit.Calendar `json:",omitempty" yaml:",omitempty"` // Working days of the resource: its work is booked on them (nil = project calendar)
+}
+
+// NewResourceID returns a new random resource ID (16 hex characters).
+func NewResourceID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic("resource ID: " + err.Error())
+	}
+	return hex.EncodeToString(b)
+}
+
+// NewResource creates a human resource with a new ID, name, role and hourly rate.
+func NewResource(name string, role string, rate unit.Price) *Resource {
+	return &Resource{
+		ID:   NewResourceID(),
+		Name: name,
+		Role: role,
+		Kind: KindHuman,
+		Rate: rate,
+	}
+}
+
+// Capacity returns the units available at the same time (MaxUnits, or 1 if not set).
+func (r *Resource) Capacity() float64 {
+	if r.MaxUnits <= 0 {
+		return 1
+	}
+	return r.MaxUnits
+}
+
+// IsAsset returns true if the resource is equipment rather than a person.
+func (r *Resource) IsAsset() bool {
+	return r.Kind == KindAsset
+}
+
+// Assignment assigns a pool resource to an activity: Units is the share of the resource used while working
+// (1 = full time) and WorkHours the total effort. The cost is the pool rate times the work hours.
+type Assignment struct {
+	Resource  *Resource
+	Units     float64
+	WorkHours float64
+}
+
+// NewAssignment creates an assignment of the resource with the given units and work hours.
+func NewAssignment(r *Resource, units float64, workHours float64) *Assignment {
+	return &Assignment{Resource: r, Units: units, WorkHours: workHours}
+}
+
+// CalculatePrice returns the cost of the assignment: pool rate times work hours.
+func (a *Assignment) CalculatePrice() float64 {
+	return a.CalculateMoney().Float64()
+}
+
+// CalculateMoney returns the exact cost of the assignment in the rate currency, rounded half-even to 4 decimals.
+func (a *Assignment) CalculateMoney() unit.Money {
+	if a.Resource == nil {
+		return unit.Money{}
+	}
+	return a.Resource.Rate.Money().Mul(a.WorkHours, unit.RoundHalfEven)
+}
+
+// Clone returns a copy of the assignment referencing the same pool resource.
+func (a *Assignment) Clone() *Assignment {
+	return NewAssignment(a.Resource, a.Units, a.WorkHours)
+}
@@ -7,6 +7,7 @@ import "explosio/core/unit"
 // Asset and HumanResource embed this to avoid code duplication.
 type PricedResource struct {
 	Name        string
+	Code        string `json:",omitempty" yaml:",omitempty"` // Catalog code: the price is resolved from the catalog rate
 	Description string
 	Price       unit.Price
 	Duration    unit.Duration
@@ -14,7 +15,12 @@ type PricedResource struct {
 
 // CalculatePrice returns the price value.
 func (p *PricedResource) CalculatePrice() float64 {
-	return p.Price.Value
+	return p.CalculateMoney().Float64()
+}
+
+// CalculateMoney returns the price as an exact amount.
+func (p *PricedResource) CalculateMoney() unit.Money {
+	return p.Price.Money()
 }
 
 // CalculateDuration returns the duration value.
@@ -22,54 +28,78 @@ func (p *PricedResource) CalculateDuration() float64 {
 	return p.Duration.Value
 }
 
-// CalculateHourlyRate returns the hourly rate.
+// SetTotalPrice sets the total price, rounded half-even to the Money scale (4 decimals).
+// Hourly and daily rates are derived from Price/Duration.
+func (p *PricedResource) SetTotalPrice(totalPrice unit.Price) {
+	p.Price = totalPrice.Money().Price()
+}
+
+// CalculateHourlyRate returns the hourly rate with the default policy (DefaultRateConversion).
 // Returns 0 if duration is zero to avoid division by zero.
 func (p *PricedResource) CalculateHourlyRate() float64 {
-	hours := p.Duration.ToHours()
-	if hours == 0 {
-		return 0
-	}
-	return p.Price.Value / hours
+	return p.CalculateHourlyRateWith(DefaultRateConversion())
 }
 
-// CalculateDailyRate returns the daily rate (per working day).
+// CalculateDailyRate returns the rate per day of the default policy (DefaultRateConversion).
 // Returns 0 if duration is zero to avoid division by zero.
 func (p *PricedResource) CalculateDailyRate() float64 {
-	hours := p.Duration.ToHours()
+	return p.CalculateDailyRateWith(DefaultRateConversion())
+}
+
+// SetHourlyRate sets the hourly rate and derives the total price from the duration converted with the default
+// policy (DefaultRateConversion). Use SetHourlyRateWith to convert with the project policy.
+func (p *PricedResource) SetHourlyRate(rate unit.Price) {
+	p.SetHourlyRateWith(rate, DefaultRateConversion())
+}
+
+// SetDailyRate sets the rate per day and derives the total price from the duration in days of the default policy
+// (DefaultRateConversion). Use SetDailyRateWith to count days with the project policy.
+func (p *PricedResource) SetDailyRate(rate unit.Price) {
+	p.SetDailyRateWith(rate, DefaultRateConversion())
+}
+
+// CalculateHourlyRateWith returns the hourly rate, converting the duration with the given policy.
+// Returns 0 if duration is zero to avoid division by zero.
+func (p *PricedResource) CalculateHourlyRateWith(conv unit.DurationConversion) float64 {
+	hours := p.Duration.ToHoursWith(conv)
 	if hours == 0 {
 		return 0
 	}
-	return p.Price.Value / hours * unit.WorkingHoursPerDay
+	return p.Price.Value / hours
 }
 
-// SetTotalPrice sets the total price. Hourly and daily rates are derived from Price/Duration.
-func (p *PricedResource) SetTotalPrice(totalPrice unit.Price) {
-	p.Price = totalPrice
+// CalculateDailyRateWith returns the rate per day of conv.HoursPerDay hours.
+// Returns 0 if duration is zero to avoid division by zero.
+func (p *PricedResource) CalculateDailyRateWith(conv unit.DurationConversion) float64 {
+	return p.CalculateHourlyRateWith(conv) * conv.HoursPerDay
 }
 
-// SetHourlyRate sets the hourly rate and derives the total price from duration.
-// If Duration.ToHours() is 0, total price is set to 0.
-func (p *PricedResource) SetHourlyRate(rate unit.Price) {
-	hours := p.Duration.ToHours()
-	if hours == 0 {
-		p.Price = unit.Price{Value: 0, Currency: rate.Currency}
-		return
-	}
-	p.Price = unit.Price{Value: rate.Value * hours, Currency: rate.Currency}
+// SetHourlyRateWith sets the hourly rate and derives the total price from duration converted with the given policy.
+// Example with working time (8h days): 2 days at 10 EUR/hour → 160 EUR.
+func (p *PricedResource) SetHourlyRateWith(rate unit.Price, conv unit.DurationConversion) {
+	p.Price = rate.Money().Mul(p.Duration.ToHoursWith(conv), unit.RoundHalfEven).Price()
 }
 
-// SetDailyRate sets the daily rate (per working day of WorkingHoursPerDay hours) and derives the total price from duration.
-// Duration is converted to calendar hours via ToHours() (e.g. 1 day = 24h). Total = rate * (hours / WorkingHoursPerDay).
-// Example: 8 hours duration, 80 EUR/day → total = 80 * 8/8 = 80 EUR. 24 hours, 80 EUR/day → total = 80 * 24/8 = 240 EUR.
-// If Duration.ToHours() is 0, total price is set to 0.
-func (p *PricedResource) SetDailyRate(rate unit.Price) {
-	hours := p.Duration.ToHours()
-	if hours == 0 {
+// SetDailyRateWith sets the rate per day and derives the total price from the duration in days of the given policy,
+// so days are counted with the same convention used by the schedule.
+// Example: 3 days at 80 EUR/day → 240 EUR with either calendar or working time; 12 hours at 80 EUR/day → 120 EUR with 8h days.
+func (p *PricedResource) SetDailyRateWith(rate unit.Price, conv unit.DurationConversion) {
+	p.Price = rate.Money().Mul(conv.Days(p.Duration.ToHoursWith(conv)), unit.RoundHalfEven).Price()
+}
+
+// SetRateWith sets a rate per the given time unit and derives the total price from the duration, both converted to
+// hours with the given policy. Example with working time (8h days, 40h weeks): 3 days at 1000 EUR/week → 600 EUR.
+func (p *PricedResource) SetRateWith(rate unit.Price, per unit.DurationUnit, conv unit.DurationConversion) {
+	perHours := conv.Hours(1, per)
+	if perHours == 0 {
 		p.Price = unit.Price{Value: 0, Currency: rate.Currency}
 		return
 	}
-	p.Price = unit.Price{
-		Value:    rate.Value * hours / unit.WorkingHoursPerDay,
-		Currency: rate.Currency,
-	}
+	p.Price = rate.Money().Mul(p.Duration.ToHoursWith(conv), unit.RoundHalfEven).Div(perHours, unit.RoundHalfEven).Price()
+}
+
+// DefaultRateConversion is the policy the builders use to turn rates into totals when no project policy is given.
+// It is unit.DefaultConversion, the policy of the Activity scheduling methods, so 1 day at 10 EUR/hour is 240 EUR.
+func DefaultRateConversion() unit.DurationConversion {
+	return unit.DefaultConversion()
 }
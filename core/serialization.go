@@ -3,6 +3,8 @@ package core
 
 import (
 	"encoding/json"
+	"explosio/core/resource"
+	"explosio/core/unit"
 	"fmt"
 	"io"
 
@@ -12,9 +14,28 @@ import (
 const ProjectVersion = "1.0"
 
 // Project wraps a root activity for file persistence. Allows adding metadata (version, name) later.
+// Calendar, if set, defines the working time used to turn the schedule into dates.
+// Conversion, if set, defines how durations are converted to hours (default: calendar time, 1 day = 24h).
+// Start is the planned project start; it is needed to honor date constraints and the Deadline (finish by the end of that date).
+// StatusDate is the date up to which progress is recorded: with a Start, the remaining work is scheduled from it.
+// Currency is the reporting currency for totals (default: the root activity currency); ExchangeRates converts the
+// other currencies into it (see currency.go).
+// Baselines are named snapshots of the planned schedule and costs (see baseline.go).
+// Resources is the project resource pool: activity assignments reference its resources by ID.
 type Project struct {
-	Version string    `json:"version" yaml:"version"`
-	Root    *Activity `json:"root" yaml:"root"`
+	Version       string                   `json:"version" yaml:"version"`
+	Root          *Activity                `json:"root" yaml:"root"`
+	Calendar      *unit.Calendar           `json:"calendar,omitempty" yaml:"calendar,omitempty"`
+	Conversion    *unit.DurationConversion `json:"conversion,omitempty" yaml:"conversion,omitempty"`
+	Start         *unit.Date               `json:"start,omitempty" yaml:"start,omitempty"`
+	Deadline      *unit.Date               `json:"deadline,omitempty" yaml:"deadline,omitempty"`
+	StatusDate    *unit.Date               `json:"statusDate,omitempty" yaml:"statusdate,omitempty"`
+	Resources     []*resource.Resource     `json:"resources,omitempty" yaml:"resources,omitempty"`
+	Baselines     []*Baseline              `json:"baselines,omitempty" yaml:"baselines,omitempty"`
+	Currency      string                   `json:"currency,omitempty" yaml:"currency,omitempty"`
+	ExchangeRates unit.ExchangeRates       `json:"exchangeRates,omitempty" yaml:"exchangerates,omitempty"`
+	Pricing       *PricingPolicy           `json:"pricing,omitempty" yaml:"pricing,omitempty"`
+	Catalog       string                   `json:"catalog,omitempty" yaml:"catalog,omitempty"` // Catalog file resolving item codes (relative to the project file)
 }
 
 // NewProject creates a project with the given root activity.
@@ -25,8 +46,245 @@ func NewProject(root *Activity) *Project {
 	}
 }
 
+// activityFields has the same fields as Activity but none of its methods,
+// so the custom marshalers below can reuse the default encoding.
+type activityFields Activity
+
+// dependencyRef is the file form of a Dependency: the predecessor is referenced by ID.
+// Type and Lag are omitted for plain finish-to-start links without lag.
+type dependencyRef struct {
+	ID   string         `json:"ID" yaml:"id"`
+	Type DependencyType `json:"Type,omitempty" yaml:"type,omitempty"`
+	Lag  *unit.Duration `json:"Lag,omitempty" yaml:"lag,omitempty"`
+}
+
+// UnmarshalJSON accepts either a reference object or a plain ID string (files written before typed links).
+func (r *dependencyRef) UnmarshalJSON(data []byte) error {
+	var id string
+	if err := json.Unmarshal(data, &id); err == nil {
+		*r = dependencyRef{ID: id}
+		return nil
+	}
+	type plain dependencyRef
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+// UnmarshalYAML accepts either a reference mapping or a plain ID scalar (files written before typed links).
+func (r *dependencyRef) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*r = dependencyRef{ID: value.Value}
+		return nil
+	}
+	type plain dependencyRef
+	return value.Decode((*plain)(r))
+}
+
+// assignmentRef is the file form of a resource.Assignment: the pool resource is referenced by ID.
+type assignmentRef struct {
+	Resource  string  `json:"Resource" yaml:"resource"`
+	Units     float64 `json:"Units" yaml:"units"`
+	WorkHours float64 `json:"WorkHours" yaml:"workhours"`
+}
+
+// activityJSON is the JSON form of an Activity: DependsOn and Assignments are written as references by ID.
+type activityJSON struct {
+	*activityFields
+	DependsOn   []dependencyRef `json:"DependsOn,omitempty"`
+	Assignments []assignmentRef `json:"Assignments,omitempty"`
+}
+
+// activityYAML is the YAML form of an Activity: DependsOn and Assignments are written as references by ID.
+type activityYAML struct {
+	*activityFields `yaml:",inline"`
+	DependsOn       []dependencyRef `yaml:"dependson,omitempty"`
+	Assignments     []assignmentRef `yaml:"assignments,omitempty"`
+}
+
+// dependsOnRefs returns the DependsOn links as references by ID.
+func (a *Activity) dependsOnRefs() []dependencyRef {
+	var refs []dependencyRef
+	for _, dep := range a.DependsOn {
+		ref := dependencyRef{ID: dep.Activity.ID}
+		if dep.kind() != FinishToStart {
+			ref.Type = dep.Type
+		}
+		if dep.Lag.Value != 0 {
+			lag := dep.Lag
+			ref.Lag = &lag
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// assignmentRefs returns the assignments as references to pool resources by ID.
+func (a *Activity) assignmentRefs() []assignmentRef {
+	var refs []assignmentRef
+	for _, as := range a.Assignments {
+		refs = append(refs, assignmentRef{Resource: as.Resource.ID, Units: as.Units, WorkHours: as.WorkHours})
+	}
+	return refs
+}
+
+// MarshalJSON encodes the activity with DependsOn and Assignments as ID references.
+func (a *Activity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(activityJSON{
+		activityFields: (*activityFields)(a),
+		DependsOn:      a.dependsOnRefs(),
+		Assignments:    a.assignmentRefs(),
+	})
+}
+
+// UnmarshalJSON decodes the activity. References are kept until Project.resolveDependencies and
+// Project.resolveAssignments link them.
+func (a *Activity) UnmarshalJSON(data []byte) error {
+	aux := activityJSON{activityFields: (*activityFields)(a)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	a.pendingDeps = aux.DependsOn
+	a.pendingAssignments = aux.Assignments
+	return nil
+}
+
+// MarshalYAML encodes the activity with DependsOn and Assignments as ID references.
+func (a *Activity) MarshalYAML() (interface{}, error) {
+	return activityYAML{
+		activityFields: (*activityFields)(a),
+		DependsOn:      a.dependsOnRefs(),
+		Assignments:    a.assignmentRefs(),
+	}, nil
+}
+
+// UnmarshalYAML decodes the activity. References are kept until Project.resolveDependencies and
+// Project.resolveAssignments link them.
+func (a *Activity) UnmarshalYAML(value *yaml.Node) error {
+	aux := activityYAML{activityFields: (*activityFields)(a)}
+	if err := value.Decode(&aux); err != nil {
+		return err
+	}
+	a.pendingDeps = aux.DependsOn
+	a.pendingAssignments = aux.Assignments
+	return nil
+}
+
+// prepareWrite assigns IDs to activities and pool resources that have none and checks that every DependsOn
+// reference points to an activity of the project and every assignment to a resource of the pool,
+// so that the written file can be read back.
+func (p *Project) prepareWrite() error {
+	if p.Root == nil {
+		return fmt.Errorf("project root is nil")
+	}
+	activities := p.Root.GetActivities()
+	inTree := make(map[*Activity]bool)
+	for _, act := range activities {
+		if act.ID == "" {
+			act.ID = NewActivityID()
+		}
+		inTree[act] = true
+	}
+	for _, act := range activities {
+		for _, dep := range act.DependsOn {
+			if dep.Activity == nil {
+				return fmt.Errorf("activity %q: DependsOn has a nil activity", act.Name)
+			}
+			if !inTree[dep.Activity] {
+				return fmt.Errorf("activity %q: DependsOn references activity %q not in project", act.Name, dep.Activity.Name)
+			}
+		}
+	}
+	inPool := make(map[*resource.Resource]bool)
+	for _, r := range p.Resources {
+		if r.ID == "" {
+			r.ID = resource.NewResourceID()
+		}
+		inPool[r] = true
+	}
+	for _, act := range activities {
+		for _, as := range act.Assignments {
+			if as.Resource == nil {
+				return fmt.Errorf("activity %q: assignment has a nil resource", act.Name)
+			}
+			if !inPool[as.Resource] {
+				return fmt.Errorf("activity %q: assigned resource %q is not in the project resource pool", act.Name, as.Resource.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveDependencies turns the DependsOn references read from file back into dependencies on activity pointers.
+// Activities without ID (files written by older versions) get a new one. Returns an error
+// for duplicate IDs, references to unknown IDs or unknown dependency types.
+func (p *Project) resolveDependencies() error {
+	activities := p.Root.GetActivities()
+	byID := make(map[string]*Activity)
+	for _, act := range activities {
+		if act.ID == "" {
+			act.ID = NewActivityID()
+		}
+		if other, ok := byID[act.ID]; ok {
+			return fmt.Errorf("duplicate activity ID %q (%q and %q)", act.ID, other.Name, act.Name)
+		}
+		byID[act.ID] = act
+	}
+	for _, act := range activities {
+		act.DependsOn = nil
+		for _, ref := range act.pendingDeps {
+			dep, ok := byID[ref.ID]
+			if !ok {
+				return fmt.Errorf("activity %q: DependsOn references unknown activity ID %q", act.Name, ref.ID)
+			}
+			if !IsValidDependencyType(ref.Type) {
+				return fmt.Errorf("activity %q: unknown dependency type %q", act.Name, ref.Type)
+			}
+			depType := ref.Type
+			if depType == "" {
+				depType = FinishToStart
+			}
+			var lag unit.Duration
+			if ref.Lag != nil {
+				lag = *ref.Lag
+			}
+			act.AddDependency(dep, depType, lag)
+		}
+		act.pendingDeps = nil
+	}
+	return nil
+}
+
+// resolveAssignments turns the assignment references read from file back into assignments of pool resources.
+// Returns an error for duplicate resource IDs or references to unknown resources.
+func (p *Project) resolveAssignments() error {
+	byID := make(map[string]*resource.Resource)
+	for _, r := range p.Resources {
+		if r.ID == "" {
+			r.ID = resource.NewResourceID()
+		}
+		if other, ok := byID[r.ID]; ok {
+			return fmt.Errorf("duplicate resource ID %q (%q and %q)", r.ID, other.Name, r.Name)
+		}
+		byID[r.ID] = r
+	}
+	for _, act := range p.Root.GetActivities() {
+		act.Assignments = nil
+		for _, ref := range act.pendingAssignments {
+			r, ok := byID[ref.Resource]
+			if !ok {
+				return fmt.Errorf("activity %q: assignment references unknown resource ID %q", act.Name, ref.Resource)
+			}
+			act.Assign(r, ref.Units, ref.WorkHours)
+		}
+		act.pendingAssignments = nil
+	}
+	return nil
+}
+
 // WriteJSON writes the project to w as formatted JSON.
 func (p *Project) WriteJSON(w io.Writer) error {
+	if err := p.prepareWrite(); err != nil {
+		return err
+	}
 	enc := json.NewEncoder(w)
 	enc.SetIndent("", "  ")
 	return enc.Encode(p)
@@ -41,11 +299,20 @@ func ReadJSON(r io.Reader) (*Project, error) {
 	if p.Root == nil {
 		return nil, fmt.Errorf("project root is nil")
 	}
+	if err := p.resolveDependencies(); err != nil {
+		return nil, err
+	}
+	if err := p.resolveAssignments(); err != nil {
+		return nil, err
+	}
 	return &p, nil
 }
 
 // WriteYAML writes the project to w as YAML.
 func (p *Project) WriteYAML(w io.Writer) error {
+	if err := p.prepareWrite(); err != nil {
+		return err
+	}
 	data, err := yaml.Marshal(p)
 	if err != nil {
 		return err
@@ -64,5 +331,11 @@ func ReadYAML(r io.Reader) (*Project, error) {
 	if p.Root == nil {
 		return nil, fmt.Errorf("project root is nil")
 	}
+	if err := p.resolveDependencies(); err != nil {
+		return nil, err
+	}
+	if err := p.resolveAssignments(); err != nil {
+		return nil, err
+	}
 	return &p, nil
 }
@@ -102,3 +102,143 @@ func TestProject_WriteReadYAML(t *testing.T) {
 		t.Errorf("Price after YAML round-trip = %.2f, want %.2f", readPrice, origPrice)
 	}
 }
+
+// buildDependencyTestTree returns Root with two branches; "Tiles" (branch B) depends on "Pipes" (branch A).
+func buildDependencyTestTree() (*Activity, *Activity, *Activity) {
+	root := NewActivity("Root", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	branchA := NewActivity("A", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	branchB := NewActivity("B", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	pipes := NewActivity("Pipes", "", *unit.NewDuration(3, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	tiles := NewActivity("Tiles", "", *unit.NewDuration(2, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	root.AddActivity(branchA)
+	root.AddActivity(branchB)
+	branchA.AddActivity(pipes)
+	branchB.AddActivity(tiles)
+	tiles.AddDependsOn(pipes)
+	return root, pipes, tiles
+}
+
+func TestProject_DependsOnRoundTrip(t *testing.T) {
+	root, pipes, tiles := buildDependencyTestTree()
+	origSlack := root.CalculateSlack()
+
+	formats := []struct {
+		name  string
+		write func(*Project, *bytes.Buffer) error
+		read  func(*bytes.Buffer) (*Project, error)
+	}{
+		{"json", func(p *Project, b *bytes.Buffer) error { return p.WriteJSON(b) }, func(b *bytes.Buffer) (*Project, error) { return ReadJSON(b) }},
+		{"yaml", func(p *Project, b *bytes.Buffer) error { return p.WriteYAML(b) }, func(b *bytes.Buffer) (*Project, error) { return ReadYAML(b) }},
+	}
+	for _, f := range formats {
+		t.Run(f.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := f.write(NewProject(root), &buf); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			read, err := f.read(&buf)
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			readTiles := read.Root.FindByID(tiles.ID)
+			readPipes := read.Root.FindByID(pipes.ID)
+			if readTiles == nil || readPipes == nil {
+				t.Fatal("activities not found by ID after round-trip")
+			}
+			if len(readTiles.DependsOn) != 1 || readTiles.DependsOn[0].Activity != readPipes {
+				t.Fatalf("Tiles.DependsOn should point to the read Pipes activity, got %v", readTiles.DependsOn)
+			}
+			readSlack := read.Root.CalculateSlack()
+			for act, info := range origSlack {
+				got := readSlack[read.Root.FindByID(act.ID)]
+				if got != info {
+					t.Errorf("%s: slack after round-trip = %+v, want %+v", act.Name, got, info)
+				}
+			}
+		})
+	}
+}
+
+func TestReadJSON_UnknownDependsOnID(t *testing.T) {
+	data := `{"version":"1.0","root":{"ID":"r","Name":"Root","Activities":[{"ID":"a","Name":"A","DependsOn":["missing"]}]}}`
+	_, err := ReadJSON(bytes.NewBufferString(data))
+	if err == nil {
+		t.Fatal("expected error for dangling DependsOn ID")
+	}
+}
+
+func TestReadJSON_DuplicateID(t *testing.T) {
+	data := `{"version":"1.0","root":{"ID":"r","Name":"Root","Activities":[{"ID":"r","Name":"A"}]}}`
+	_, err := ReadJSON(bytes.NewBufferString(data))
+	if err == nil {
+		t.Fatal("expected error for duplicate activity ID")
+	}
+}
+
+func TestReadJSON_LegacyFileWithoutIDs(t *testing.T) {
+	data := `{"version":"1.0","root":{"Name":"Root","DependsOn":null,"Activities":[{"Name":"A"},{"Name":"B"}]}}`
+	p, err := ReadJSON(bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatalf("ReadJSON: %v", err)
+	}
+	seen := make(map[string]bool)
+	for _, act := range p.Root.GetActivities() {
+		if act.ID == "" || seen[act.ID] {
+			t.Errorf("activity %q has empty or duplicate ID %q", act.Name, act.ID)
+		}
+		seen[act.ID] = true
+	}
+}
+
+func TestProject_WriteJSON_DependsOnOutsideProject(t *testing.T) {
+	root := NewActivity("Root", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	outside := NewActivity("Outside", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	root.AddDependsOn(outside)
+	var buf bytes.Buffer
+	if err := NewProject(root).WriteJSON(&buf); err == nil {
+		t.Fatal("expected error for DependsOn outside the project")
+	}
+}
+
+func TestProject_DependencyTypeAndLagRoundTrip(t *testing.T) {
+	root, pipes, tiles := buildDependencyTestTree()
+	tiles.DependsOn = nil
+	tiles.AddDependency(pipes, StartToStart, *unit.NewDuration(-1, unit.DurationUnitDay))
+
+	for _, format := range []string{"json", "yaml"} {
+		var buf bytes.Buffer
+		var read *Project
+		var err error
+		if format == "json" {
+			if err = NewProject(root).WriteJSON(&buf); err == nil {
+				read, err = ReadJSON(&buf)
+			}
+		} else {
+			if err = NewProject(root).WriteYAML(&buf); err == nil {
+				read, err = ReadYAML(&buf)
+			}
+		}
+		if err != nil {
+			t.Fatalf("%s round-trip: %v", format, err)
+		}
+		deps := read.Root.FindByID(tiles.ID).DependsOn
+		if len(deps) != 1 {
+			t.Fatalf("%s: got %d dependencies, want 1", format, len(deps))
+		}
+		if deps[0].Type != StartToStart || deps[0].Lag.Value != -1 || deps[0].Lag.Unit != unit.DurationUnitDay {
+			t.Errorf("%s: dependency = %+v, want SS with -1 day lag", format, deps[0])
+		}
+	}
+}
+
+func TestReadJSON_DependsOnPlainIDs(t *testing.T) {
+	data := `{"version":"1.0","root":{"ID":"r","Name":"Root","Activities":[{"ID":"a","Name":"A"},{"ID":"b","Name":"B","DependsOn":["a"]}]}}`
+	p, err := ReadJSON(bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatalf("ReadJSON: %v", err)
+	}
+	deps := p.Root.FindByID("b").DependsOn
+	if len(deps) != 1 || deps[0].Activity != p.Root.FindByID("a") || deps[0].Type != FinishToStart {
+		t.Errorf("plain ID reference should become a finish-to-start dependency, got %+v", deps)
+	}
+}
@@ -0,0 +1,337 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"explosio/core/unit"
+)
+
+// Distribution is the probability distribution used to sample a three-point estimate.
+type Distribution string
+
+const (
+	DistributionPERT       Distribution = "pert"       // PERT-beta (default): weighted towards the most likely value
+	DistributionTriangular Distribution = "triangular" // Triangular between optimistic and pessimistic
+	DistributionUniform    Distribution = "uniform"    // Uniform between optimistic and pessimistic (most likely ignored)
+)
+
+// IsValidDistribution returns true if d is a known distribution (or empty, meaning PERT-beta).
+func IsValidDistribution(d Distribution) bool {
+	switch d {
+	case "", DistributionPERT, DistributionTriangular, DistributionUniform:
+		return true
+	}
+	return false
+}
+
+// CostEstimate holds optimistic, most likely and pessimistic values of the activity's own price (Price.Value,
+// in the price currency) for Monte Carlo simulation. Only the own price is sampled: materials and resources keep
+// their computed price, and the sampled value is converted into the reporting currency like the price itself.
+type CostEstimate struct {
+	Optimistic   float64
+	MostLikely   float64
+	Pessimistic  float64
+	Distribution Distribution `json:",omitempty" yaml:",omitempty"`
+}
+
+// valid returns true if 0 <= optimistic <= most likely <= pessimistic and the distribution is known.
+func (c *CostEstimate) valid() bool {
+	return c.Optimistic >= 0 && c.Optimistic <= c.MostLikely && c.MostLikely <= c.Pessimistic && IsValidDistribution(c.Distribution)
+}
+
+// sample draws a value between lo and hi with the given most likely value.
+func (d Distribution) sample(rng *rand.Rand, lo, mode, hi float64) float64 {
+	if hi <= lo {
+		return mode
+	}
+	switch d {
+	case DistributionUniform:
+		return lo + rng.Float64()*(hi-lo)
+	case DistributionTriangular:
+		u := rng.Float64()
+		if u < (mode-lo)/(hi-lo) {
+			return lo + math.Sqrt(u*(hi-lo)*(mode-lo))
+		}
+		return hi - math.Sqrt((1-u)*(hi-lo)*(hi-mode))
+	default:
+		alpha := 1 + 4*(mode-lo)/(hi-lo)
+		beta := 1 + 4*(hi-mode)/(hi-lo)
+		x := sampleGamma(rng, alpha)
+		y := sampleGamma(rng, beta)
+		return lo + x/(x+y)*(hi-lo)
+	}
+}
+
+// sampleGamma draws from Gamma(k, 1) for k >= 1 (Marsaglia and Tsang).
+func sampleGamma(rng *rand.Rand, k float64) float64 {
+	d := k - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := rng.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := rng.Float64()
+		if u < 1-0.0331*x*x*x*x || math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
+
+// SimulationConfig holds the options of a Monte Carlo simulation.
+type SimulationConfig struct {
+	Runs    int       // Number of runs (default 1000)
+	Seed    int64     // Base seed: the same seed gives the same result, whatever the number of workers
+	Workers int       // Concurrent workers (default: number of CPUs)
+	Start   unit.Date // Project start, used for finish dates and date constraints
+}
+
+// SimulationResult holds the outcome of a Monte Carlo simulation.
+type SimulationResult struct {
+	Runs        int
+	Durations   []float64             // Project duration of each run (hours), sorted
+	Costs       []float64             // Project cost of each run in Currency, sorted
+	Criticality map[*Activity]float64 // Fraction of runs in which the activity is critical (0..1)
+	Currency    string                // Reporting currency of the costs
+
+	start unit.Date
+	cal   *unit.Calendar
+	conv  unit.DurationConversion
+}
+
+// simulationRun is the outcome of a single run.
+type simulationRun struct {
+	duration float64
+	cost     float64
+	critical []*Activity
+}
+
+// Simulate runs a Monte Carlo simulation with calendar-time conversion; see Project.Simulate for the project settings.
+// Durations are sampled from the three-point estimates (activities without one keep their Duration) and the own
+// price from the cost estimates; each run computes the CPM schedule. Costs are summed without currency conversion,
+// as CalculatePrice does.
+func (a *Activity) Simulate(cfg SimulationConfig) *SimulationResult {
+	r, _ := a.simulate(cfg, defaultScheduleOptions(), nil)
+	return r
+}
+
+// simulate runs the simulation. With a currency conversion the base cost and the sampled prices are converted
+// into its currency; it fails if a currency has no exchange rate on the conversion date.
+func (a *Activity) simulate(cfg SimulationConfig, opts scheduleOptions, c *CurrencyConversion) (*SimulationResult, error) {
+	if cfg.Runs <= 0 {
+		cfg.Runs = 1000
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = runtime.NumCPU()
+	}
+	opts.start = &cfg.Start
+	activities := a.GetActivities()
+	basePrice := a.CalculatePrice()
+	currency := a.Price.Currency
+	// rates converts the sampled own price of each activity with a cost estimate into the reporting currency.
+	rates := make(map[*Activity]float64)
+	for _, act := range activities {
+		if act.CostEstimate != nil {
+			rates[act] = 1
+		}
+	}
+	if c != nil {
+		mb, err := a.MoneyBreakdownIn(*c)
+		if err != nil {
+			return nil, err
+		}
+		basePrice, currency = mb.Total().Float64(), c.Currency
+		for act := range rates {
+			rate, err := c.Rates.Rate(act.Price.Currency, c.Currency, c.Date)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", act.Name, err)
+			}
+			rates[act] = rate
+		}
+	}
+
+	runs := make([]simulationRun, cfg.Runs)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < cfg.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				runs[i] = a.simulateRun(activities, basePrice, rates, runSeed(cfg.Seed, i), opts)
+			}
+		}()
+	}
+	for i := 0; i < cfg.Runs; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	r := &SimulationResult{
+		Runs:        cfg.Runs,
+		Criticality: make(map[*Activity]float64),
+		Currency:    currency,
+		start:       cfg.Start,
+		cal:         opts.cal,
+		conv:        opts.conv,
+	}
+	for _, run := range runs {
+		r.Durations = append(r.Durations, run.duration)
+		r.Costs = append(r.Costs, run.cost)
+		for _, act := range run.critical {
+			r.Criticality[act]++
+		}
+	}
+	for act := range r.Criticality {
+		r.Criticality[act] /= float64(cfg.Runs)
+	}
+	sort.Float64s(r.Durations)
+	sort.Float64s(r.Costs)
+	return r, nil
+}
+
+// simulateRun samples durations and costs and runs the CPM once. The difference between the sampled and the
+// planned own price is added to the base price with the rate of the activity.
+func (a *Activity) simulateRun(activities []*Activity, basePrice float64, rates map[*Activity]float64, seed int64, opts scheduleOptions) simulationRun {
+	rng := rand.New(rand.NewSource(seed))
+	opts.durations = make(map[*Activity]float64)
+	cost := basePrice
+	for _, act := range activities {
+		if e := act.Estimate; e != nil {
+			o, m, p := e.hoursWith(opts.conv)
+			opts.durations[act] = e.Distribution.sample(rng, o, m, p)
+		}
+		if c := act.CostEstimate; c != nil {
+			cost += (c.Distribution.sample(rng, c.Optimistic, c.MostLikely, c.Pessimistic) - act.Price.Value) * rates[act]
+		}
+	}
+	m, projectEnd := a.calculateSlack(opts)
+	return simulationRun{duration: projectEnd, cost: cost, critical: criticalPathFromSlack(m, 0)}
+}
+
+// runSeed derives the seed of run i from the base seed (splitmix64), so runs are independent and reproducible.
+func runSeed(seed int64, i int) int64 {
+	z := uint64(seed) + uint64(i+1)*0x9E3779B97F4A7C15
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return int64(z ^ (z >> 31))
+}
+
+// percentile returns the p-th percentile (0..100) of sorted values (nearest rank).
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// DurationPercentile returns the project duration (hours) not exceeded in p percent of the runs.
+func (r *SimulationResult) DurationPercentile(p float64) float64 {
+	return percentile(r.Durations, p)
+}
+
+// CostPercentile returns the project cost not exceeded in p percent of the runs.
+func (r *SimulationResult) CostPercentile(p float64) float64 {
+	return percentile(r.Costs, p)
+}
+
+// FinishDate returns the finish date of a project duration (hours), using the simulation start and calendar.
+func (r *SimulationResult) FinishDate(hours float64) unit.Date {
+	_, end := scheduleDates(r.start, r.cal, r.conv, 0, hours)
+	return end
+}
+
+// HistogramBin is a bin of a duration histogram.
+type HistogramBin struct {
+	From  float64 // Lower bound (hours, inclusive)
+	To    float64 // Upper bound (hours; inclusive for the last bin)
+	Count int
+}
+
+// Histogram returns the distribution of the project duration in the given number of bins of equal width.
+func (r *SimulationResult) Histogram(bins int) []HistogramBin {
+	if bins <= 0 || len(r.Durations) == 0 {
+		return nil
+	}
+	lo, hi := r.Durations[0], r.Durations[len(r.Durations)-1]
+	width := (hi - lo) / float64(bins)
+	result := make([]HistogramBin, bins)
+	for i := range result {
+		result[i].From = lo + float64(i)*width
+		result[i].To = lo + float64(i+1)*width
+	}
+	for _, d := range r.Durations {
+		i := bins - 1
+		if width > 0 {
+			i = int((d - lo) / width)
+		}
+		if i >= bins {
+			i = bins - 1
+		}
+		result[i].Count++
+	}
+	return result
+}
+
+// Simulate runs a Monte Carlo simulation with the project settings (conversion policy, calendar, constraints, deadline).
+// Costs are in the reporting currency, converted with the exchange rates effective on the conversion date;
+// it fails if a currency has no exchange rate on that date.
+func (p *Project) Simulate(cfg SimulationConfig) (*SimulationResult, error) {
+	c := p.CurrencyConversion("", p.ConversionDate())
+	return p.Root.simulate(cfg, p.scheduleOptions(), &c)
+}
+
+// PrintSimulation prints the finish date and cost percentiles, the criticality index of the activities
+// (in tree order) and a histogram of the project duration.
+func PrintSimulation(r *SimulationResult, activities []*Activity, bins int) {
+	fmt.Println("--------------------------------")
+	fmt.Printf("   Monte Carlo Simulation (%d runs)\n", r.Runs)
+	fmt.Println("--------------------------------")
+	fmt.Printf("%-6s %12s %12s %16s\n", "", "Hours", "Finish", "Cost")
+	for _, p := range []float64{50, 80, 95} {
+		h := r.DurationPercentile(p)
+		fmt.Printf("P%-5.0f %12.1f %12s %12.2f %s\n", p, h, r.FinishDate(h).String(), r.CostPercentile(p), r.Currency)
+	}
+	fmt.Println("--------------------------------")
+	fmt.Println("Criticality index:")
+	for _, act := range activities {
+		name := act.Name
+		if len(name) > 24 {
+			name = name[:21] + "..."
+		}
+		fmt.Printf("  %-24s %5.1f%%\n", name, r.Criticality[act]*100)
+	}
+	fmt.Println("--------------------------------")
+	fmt.Println("Duration histogram (hours):")
+	hist := r.Histogram(bins)
+	maxCount := 0
+	for _, b := range hist {
+		if b.Count > maxCount {
+			maxCount = b.Count
+		}
+	}
+	for _, b := range hist {
+		barLen := 0
+		if maxCount > 0 {
+			barLen = b.Count * 40 / maxCount
+		}
+		bar := strings.Repeat("█", barLen) + strings.Repeat(" ", 40-barLen)
+		fmt.Printf("  %8.1f - %8.1f |%s| %d\n", b.From, b.To, bar, b.Count)
+	}
+}
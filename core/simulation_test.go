@@ -0,0 +1,143 @@
+package core
+
+import (
+	"bytes"
+	"explosio/core/unit"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestDistribution_SampleBoundsAndMean(t *testing.T) {
+	tests := []struct {
+		dist Distribution
+		mean float64
+	}{
+		{DistributionUniform, 5},
+		{DistributionTriangular, (0 + 2 + 10) / 3.0},
+		{DistributionPERT, (0 + 4*2 + 10) / 6.0},
+	}
+	for _, tt := range tests {
+		rng := rand.New(rand.NewSource(1))
+		sum := 0.0
+		const n = 20000
+		for i := 0; i < n; i++ {
+			v := tt.dist.sample(rng, 0, 2, 10)
+			if v < 0 || v > 10 {
+				t.Fatalf("%s: sample %v out of [0, 10]", tt.dist, v)
+			}
+			sum += v
+		}
+		if mean := sum / n; math.Abs(mean-tt.mean) > 0.1 {
+			t.Errorf("%s: mean = %v, want ~%v", tt.dist, mean, tt.mean)
+		}
+	}
+}
+
+func TestSimulate_Reproducible(t *testing.T) {
+	root, plaster, tiling := buildLinkTestTree()
+	plaster.SetEstimate(NewThreePointEstimate(2, 4, 8, unit.DurationUnitDay))
+	tiling.SetEstimate(&ThreePointEstimate{
+		Optimistic:   *unit.NewDuration(2, unit.DurationUnitDay),
+		MostLikely:   *unit.NewDuration(3, unit.DurationUnitDay),
+		Pessimistic:  *unit.NewDuration(9, unit.DurationUnitDay),
+		Distribution: DistributionTriangular,
+	})
+	tiling.CostEstimate = &CostEstimate{Optimistic: 100, MostLikely: 200, Pessimistic: 500, Distribution: DistributionUniform}
+
+	a := root.Simulate(SimulationConfig{Runs: 500, Seed: 7, Workers: 1})
+	b := root.Simulate(SimulationConfig{Runs: 500, Seed: 7, Workers: 8})
+	for i := range a.Durations {
+		if a.Durations[i] != b.Durations[i] || a.Costs[i] != b.Costs[i] {
+			t.Fatalf("run %d differs with the same seed: %v/%v vs %v/%v", i, a.Durations[i], a.Costs[i], b.Durations[i], b.Costs[i])
+		}
+	}
+	if a.Criticality[tiling] != b.Criticality[tiling] {
+		t.Error("criticality differs with the same seed")
+	}
+	c := root.Simulate(SimulationConfig{Runs: 500, Seed: 8})
+	if c.DurationPercentile(50) == a.DurationPercentile(50) && c.CostPercentile(50) == a.CostPercentile(50) {
+		t.Error("a different seed should give different results")
+	}
+
+	if got := a.Criticality[root]; got != 1 {
+		t.Errorf("Root criticality = %v, want 1", got)
+	}
+	if sum := a.Criticality[plaster] + a.Criticality[tiling]; sum < 1 {
+		t.Errorf("Plaster + Tiling criticality = %v, want >= 1", sum)
+	}
+	if p50, p95 := a.DurationPercentile(50), a.DurationPercentile(95); p50 > p95 || p50 < 48 || p95 > 216 {
+		t.Errorf("P50 = %v, P95 = %v out of range", p50, p95)
+	}
+	if p := a.CostPercentile(95); p < 100 || p > 500 {
+		t.Errorf("cost P95 = %v, want within [100, 500]", p)
+	}
+	total := 0
+	for _, bin := range a.Histogram(10) {
+		total += bin.Count
+	}
+	if total != 500 {
+		t.Errorf("histogram counts = %d, want 500", total)
+	}
+}
+
+func TestProject_SimulateConvertsCosts(t *testing.T) {
+	proj, tiling := buildCurrencyProject()
+	tiling.Price = *unit.NewPrice(100, "CHF")
+	tiling.CostEstimate = &CostEstimate{Optimistic: 200, MostLikely: 200, Pessimistic: 200}
+
+	r, err := proj.Simulate(SimulationConfig{Runs: 10, Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// June rate 1.10: tiles 220, labor 200, sampled price 200 CHF = 220 EUR.
+	if got := r.CostPercentile(50); math.Abs(got-640) > 1e-9 || r.Currency != "EUR" {
+		t.Errorf("cost = %v %s, want 640 EUR", got, r.Currency)
+	}
+
+	tiling.Price.Currency = "USD"
+	if _, err := proj.Simulate(SimulationConfig{Runs: 10, Seed: 1}); err == nil {
+		t.Error("expected an error for a price without exchange rate")
+	}
+}
+
+func TestSimulate_WithoutEstimatesIsDeterministic(t *testing.T) {
+	root, plaster, _ := buildLinkTestTree()
+	plaster.Price.Value = 300
+
+	r := root.Simulate(SimulationConfig{Runs: 20, Seed: 1})
+	if r.DurationPercentile(5) != 96 || r.DurationPercentile(95) != 96 {
+		t.Errorf("durations = %v, want all 96", r.Durations)
+	}
+	if r.CostPercentile(50) != root.CalculatePrice() {
+		t.Errorf("cost = %v, want %v", r.CostPercentile(50), root.CalculatePrice())
+	}
+	if got := r.Criticality[plaster]; got != 1 {
+		t.Errorf("Plaster criticality = %v, want 1", got)
+	}
+	if bins := r.Histogram(4); bins[3].Count != 20 {
+		t.Errorf("histogram = %v, want all runs in the last bin", bins)
+	}
+}
+
+func TestCostEstimate_ValidationAndRoundTrip(t *testing.T) {
+	root, plaster, _ := buildLinkTestTree()
+	plaster.CostEstimate = &CostEstimate{Optimistic: 10, MostLikely: 20, Pessimistic: 30, Distribution: "gauss"}
+	if r := root.Validate(); r.Valid() {
+		t.Error("expected an error for an unknown distribution")
+	}
+	plaster.CostEstimate.Distribution = DistributionTriangular
+
+	var buf bytes.Buffer
+	if err := NewProject(root).WriteYAML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ReadYAML(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := loaded.Root.Activities[0].CostEstimate
+	if c == nil || *c != *plaster.CostEstimate {
+		t.Errorf("CostEstimate = %+v, want %+v", c, plaster.CostEstimate)
+	}
+}
@@ -2,7 +2,9 @@ package core
 
 import (
 	"explosio/core/unit"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestCalculateSlack_NonCriticalHasSlack(t *testing.T) {
@@ -34,3 +36,148 @@ func TestCalculateSlack_NonCriticalHasSlack(t *testing.T) {
 		t.Errorf("Short slack should be ~48h, got %.2f", info.Slack)
 	}
 }
+
+func TestProject_CalculateSlack_WorkingTime(t *testing.T) {
+	root := NewActivity("Root", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	long := NewActivity("Long", "", *unit.NewDuration(3, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	short := NewActivity("Short", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	root.AddActivity(long)
+	root.AddActivity(short)
+
+	proj := NewProject(root)
+	working := unit.WorkingTimeConversion(8, 5)
+	proj.Conversion = &working
+
+	slackMap := proj.CalculateSlack()
+	// Short has 1 day, Long has 3 days. Slack = 2 working days = 16 hours
+	if got := slackMap[short].Slack; got != 16 {
+		t.Errorf("Short slack with working time = %v, want 16", got)
+	}
+	if got := slackMap[root].EF; got != 32 {
+		t.Errorf("Root EF with working time = %v, want 32", got)
+	}
+}
+
+func TestFilterActivities_DurationWithConversion(t *testing.T) {
+	a := NewActivity("A", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	working := unit.WorkingTimeConversion(8, 5)
+	if got := FilterActivities([]*Activity{a}, FilterOptions{DurationMax: 10}); len(got) != 0 {
+		t.Error("1 day (24 calendar hours) should not pass DurationMax 10")
+	}
+	if got := FilterActivities([]*Activity{a}, FilterOptions{DurationMax: 10, Conversion: &working}); len(got) != 1 {
+		t.Error("1 day (8 working hours) should pass DurationMax 10")
+	}
+}
+
+func TestCalculateSlack_FreeFloat(t *testing.T) {
+	// Root (0d) -> A (1d) -> B (1d), and C (4d). B depends on A via the tree.
+	// A has total float 2d but no free float (delaying A delays B); B has 2d of both.
+	root := NewActivity("Root", "", *unit.NewDuration(0, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	a := NewActivity("A", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	b := NewActivity("B", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	c := NewActivity("C", "", *unit.NewDuration(4, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	root.AddActivity(a)
+	a.AddActivity(b)
+	root.AddActivity(c)
+
+	check := func(mode string, m map[*Activity]SlackInfo) {
+		if got := m[a].TotalFloat; got != 48 {
+			t.Errorf("%s: A total float = %v, want 48", mode, got)
+		}
+		if got := m[a].FreeFloat; got != 0 {
+			t.Errorf("%s: A free float = %v, want 0", mode, got)
+		}
+		if got := m[b].FreeFloat; got != 48 {
+			t.Errorf("%s: B free float = %v, want 48", mode, got)
+		}
+		if m[a].Slack != m[a].TotalFloat {
+			t.Errorf("%s: Slack should equal TotalFloat", mode)
+		}
+		if got := m[a].InterferingFloat; got != 48 {
+			t.Errorf("%s: A interfering float = %v, want 48", mode, got)
+		}
+		// B can only slip if A starts early: with A late, B has no independent float.
+		if got := m[b].IndependentFloat; got != 0 {
+			t.Errorf("%s: B independent float = %v, want 0", mode, got)
+		}
+		if got := m[c].TotalFloat + m[c].FreeFloat + m[c].IndependentFloat; got != 0 {
+			t.Errorf("%s: C (critical) floats should be 0, got %v", mode, got)
+		}
+	}
+	check("tree", root.CalculateSlack())
+
+	// Same network through the full CPM (an explicit dependency that is already implied by the tree).
+	b.AddDependsOn(a)
+	check("cpm", root.CalculateSlack())
+}
+
+func TestCalculateCriticalPath_LowestFloat(t *testing.T) {
+	root, plaster, tiling := buildLinkTestTree()
+	proj := NewProject(root)
+	start := unit.NewDate(2026, time.March, 2)
+	deadline := unit.NewDate(2026, time.March, 3)
+	proj.Start = &start
+	proj.Deadline = &deadline
+
+	// The deadline (48h) is missed by everything: Plaster is 48h late, Tiling 24h late.
+	if got := proj.CalculateCriticalPath(); len(got) != 3 {
+		t.Errorf("zero-float path = %d activities, want 3", len(got))
+	}
+	path := proj.CalculateCriticalPathWithMode(CriticalPathLowestFloat)
+	set := make(map[*Activity]bool)
+	for _, a := range path {
+		set[a] = true
+	}
+	if !set[root] || !set[plaster] || set[tiling] {
+		t.Errorf("lowest-float path should be Root, Plaster; got %v", path)
+	}
+}
+
+func TestProject_Validate_NegativeFloat(t *testing.T) {
+	root, _, _ := buildLinkTestTree()
+	proj := NewProject(root)
+	start := unit.NewDate(2026, time.March, 2)
+	deadline := unit.NewDate(2026, time.March, 3)
+	proj.Start = &start
+	proj.Deadline = &deadline
+
+	r := proj.Validate()
+	var negative int
+	for _, w := range r.Warnings {
+		if strings.Contains(w.Message, "negative float") {
+			negative++
+		}
+	}
+	// Root, Plaster and Tiling all finish after the deadline.
+	if negative != 3 {
+		t.Errorf("negative float warnings = %d, want 3: %v", negative, r.Warnings)
+	}
+}
+
+func TestCalculateSlack_IndependentFloat(t *testing.T) {
+	// Root (0d) with A (1d) and C (4d); B (1d) depends on A but is not its child.
+	// B can start at 1d (A early) or 1d later if A slips: with A late (LF 3d), B still fits before 4d.
+	root := NewActivity("Root", "", *unit.NewDuration(0, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	a := NewActivity("A", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	b := NewActivity("B", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	c := NewActivity("C", "", *unit.NewDuration(4, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	root.AddActivity(a)
+	root.AddActivity(b)
+	root.AddActivity(c)
+	b.AddDependsOn(a)
+
+	m := root.CalculateSlack()
+	// A: LF 3d (B must start by 3d). B: ES 1d, LF 4d. With A finishing at 3d, B ends at 4d: no independent float.
+	if got := m[a].LF; got != 72 {
+		t.Errorf("A LF = %v, want 72", got)
+	}
+	if got := m[b].FreeFloat; got != 48 {
+		t.Errorf("B free float = %v, want 48", got)
+	}
+	if got := m[b].IndependentFloat; got != 0 {
+		t.Errorf("B independent float = %v, want 0", got)
+	}
+	if got := m[a].InterferingFloat; got != 48 {
+		t.Errorf("A interfering float = %v, want 48", got)
+	}
+}
@@ -0,0 +1,142 @@
+package unit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxCalendarDays bounds the search for working time, so a calendar without working days cannot loop forever.
+const maxCalendarDays = 366 * 100
+
+// CalendarException overrides the working hours of a single date (0 = non-working day, e.g. a plant shutdown;
+// > 0 = working day, e.g. a Saturday shift).
+type CalendarException struct {
+	Date  Date
+	Hours float64
+}
+
+// Calendar defines working time for scheduling: working weekdays, working hours per day, holidays and exceptions.
+type Calendar struct {
+	WorkingDays []time.Weekday // Working weekdays (0 = Sunday ... 6 = Saturday)
+	HoursPerDay float64        // Working hours in a working day
+	Holidays    []Date         // Non-working dates
+	Exceptions  []CalendarException
+}
+
+// NewCalendar returns the standard calendar: Monday to Friday, WorkingHoursPerDay hours per day, no holidays.
+func NewCalendar() *Calendar {
+	return &Calendar{
+		WorkingDays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		HoursPerDay: WorkingHoursPerDay,
+	}
+}
+
+// AddHoliday adds a non-working date and returns the calendar for chaining.
+func (c *Calendar) AddHoliday(d Date) *Calendar {
+	c.Holidays = append(c.Holidays, d)
+	return c
+}
+
+// AddException sets the working hours for a single date and returns the calendar for chaining.
+func (c *Calendar) AddException(d Date, hours float64) *Calendar {
+	c.Exceptions = append(c.Exceptions, CalendarException{Date: d, Hours: hours})
+	return c
+}
+
+// WorkingHoursOn returns the working hours on the given date. Exceptions take precedence over holidays,
+// holidays over the weekly pattern.
+func (c *Calendar) WorkingHoursOn(d Date) float64 {
+	day := d.String()
+	for _, e := range c.Exceptions {
+		if e.Date.String() == day {
+			return e.Hours
+		}
+	}
+	for _, h := range c.Holidays {
+		if h.String() == day {
+			return 0
+		}
+	}
+	wd := d.Time.Weekday()
+	for _, w := range c.WorkingDays {
+		if w == wd {
+			return c.HoursPerDay
+		}
+	}
+	return 0
+}
+
+// IsWorkingDay returns true if the date has working hours.
+func (c *Calendar) IsWorkingDay(d Date) bool {
+	return c.WorkingHoursOn(d) > 0
+}
+
+// StartAt returns the date on which work starts after the given working hours have elapsed from the beginning of start.
+// If the hours end exactly at the end of a working day, the next working day is returned.
+func (c *Calendar) StartAt(start Date, hours float64) Date {
+	d := start.StartOfDay()
+	remaining := hours
+	for i := 0; i < maxCalendarDays; i++ {
+		if h := c.WorkingHoursOn(d); h > 0 {
+			if remaining < h {
+				return d
+			}
+			remaining -= h
+		}
+		d = d.AddDays(1)
+	}
+	return start.AddHours(hours)
+}
+
+// FinishAt returns the date on which work finishes after the given working hours have elapsed from the beginning of start.
+// If the hours end exactly at the end of a working day, that day is returned. Zero hours finish on StartAt(start, 0).
+func (c *Calendar) FinishAt(start Date, hours float64) Date {
+	if hours <= 0 {
+		return c.StartAt(start, 0)
+	}
+	d := start.StartOfDay()
+	remaining := hours
+	for i := 0; i < maxCalendarDays; i++ {
+		if h := c.WorkingHoursOn(d); h > 0 {
+			if remaining <= h {
+				return d
+			}
+			remaining -= h
+		}
+		d = d.AddDays(1)
+	}
+	return start.AddHours(hours)
+}
+
+// WorkingHoursBetween returns the working hours from the beginning of from to the beginning of to.
+// The result is negative if to is before from.
+func (c *Calendar) WorkingHoursBetween(from, to Date) float64 {
+	from, to = from.StartOfDay(), to.StartOfDay()
+	sign := 1.0
+	if to.Time.Before(from.Time) {
+		from, to = to, from
+		sign = -1
+	}
+	total := 0.0
+	for d := from; d.Time.Before(to.Time); d = d.AddDays(1) {
+		total += c.WorkingHoursOn(d)
+	}
+	return sign * total
+}
+
+// String describes the calendar (e.g. "Mon Tue Wed Thu Fri, 8h/day, 2 holidays").
+func (c *Calendar) String() string {
+	var days []string
+	for _, w := range c.WorkingDays {
+		days = append(days, w.String()[:3])
+	}
+	s := fmt.Sprintf("%s, %.0fh/day", strings.Join(days, " "), c.HoursPerDay)
+	if len(c.Holidays) > 0 {
+		s += fmt.Sprintf(", %d holidays", len(c.Holidays))
+	}
+	if len(c.Exceptions) > 0 {
+		s += fmt.Sprintf(", %d exceptions", len(c.Exceptions))
+	}
+	return s
+}
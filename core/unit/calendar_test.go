@@ -0,0 +1,93 @@
+package unit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendar_WorkingHoursOn(t *testing.T) {
+	cal := NewCalendar()
+	cal.AddHoliday(NewDate(2026, time.December, 25))
+	cal.AddException(NewDate(2026, time.December, 19), 4) // Saturday shift
+
+	tests := []struct {
+		name string
+		date Date
+		want float64
+	}{
+		{"monday", NewDate(2026, time.December, 14), 8},
+		{"saturday", NewDate(2026, time.December, 12), 0},
+		{"holiday on friday", NewDate(2026, time.December, 25), 0},
+		{"saturday exception", NewDate(2026, time.December, 19), 4},
+	}
+	for _, tt := range tests {
+		if got := cal.WorkingHoursOn(tt.date); got != tt.want {
+			t.Errorf("%s: WorkingHoursOn(%s) = %v, want %v", tt.name, tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestCalendar_StartAtFinishAt(t *testing.T) {
+	cal := NewCalendar()
+	friday := NewDate(2026, time.December, 11)
+
+	tests := []struct {
+		name       string
+		hours      float64
+		wantStart  string
+		wantFinish string
+	}{
+		{"zero hours", 0, "2026-12-11", "2026-12-11"},
+		{"half day", 4, "2026-12-11", "2026-12-11"},
+		{"one day", 8, "2026-12-14", "2026-12-11"},
+		{"two days skip weekend", 16, "2026-12-15", "2026-12-14"},
+	}
+	for _, tt := range tests {
+		if got := cal.StartAt(friday, tt.hours).String(); got != tt.wantStart {
+			t.Errorf("%s: StartAt = %s, want %s", tt.name, got, tt.wantStart)
+		}
+		if got := cal.FinishAt(friday, tt.hours).String(); got != tt.wantFinish {
+			t.Errorf("%s: FinishAt = %s, want %s", tt.name, got, tt.wantFinish)
+		}
+	}
+}
+
+func TestCalendar_StartOnWeekend(t *testing.T) {
+	cal := NewCalendar()
+	saturday := NewDate(2026, time.December, 12)
+	if got := cal.StartAt(saturday, 0).String(); got != "2026-12-14" {
+		t.Errorf("StartAt(saturday, 0) = %s, want 2026-12-14", got)
+	}
+}
+
+func TestCalendar_NoWorkingDays(t *testing.T) {
+	cal := &Calendar{}
+	start := NewDate(2026, time.January, 1)
+	if got := cal.FinishAt(start, 24).String(); got != "2026-01-02" {
+		t.Errorf("FinishAt without working days = %s, want continuous fallback 2026-01-02", got)
+	}
+}
+
+func TestDate_MarshalText(t *testing.T) {
+	d := NewDate(2026, time.March, 5)
+	text, err := d.MarshalText()
+	if err != nil || string(text) != "2026-03-05" {
+		t.Fatalf("MarshalText() = %q, %v", text, err)
+	}
+	var back Date
+	if err := back.UnmarshalText(text); err != nil || !back.Time.Equal(d.Time) {
+		t.Errorf("UnmarshalText(%q) = %v, %v", text, back, err)
+	}
+}
+
+func TestCalendar_WorkingHoursBetween(t *testing.T) {
+	cal := NewCalendar()
+	friday := NewDate(2026, time.December, 11)
+	tuesday := NewDate(2026, time.December, 15)
+	if got := cal.WorkingHoursBetween(friday, tuesday); got != 16 {
+		t.Errorf("WorkingHoursBetween(fri, tue) = %v, want 16", got)
+	}
+	if got := cal.WorkingHoursBetween(tuesday, friday); got != -16 {
+		t.Errorf("WorkingHoursBetween(tue, fri) = %v, want -16", got)
+	}
+}
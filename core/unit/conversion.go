@@ -0,0 +1,79 @@
+package unit
+
+// TimeBasis tells whether durations measure elapsed calendar time or working time.
+type TimeBasis string
+
+const (
+	TimeBasisCalendar TimeBasis = "calendar" // 1 day = 24 hours of elapsed time
+	TimeBasisWorking  TimeBasis = "working"  // 1 day = the working hours of a day
+)
+
+// DurationConversion is the policy used to convert durations to hours.
+// Minutes and hours are always converted exactly; days, weeks, months and years use the configured hours.
+type DurationConversion struct {
+	Basis         TimeBasis
+	HoursPerDay   float64
+	HoursPerWeek  float64
+	HoursPerMonth float64
+	HoursPerYear  float64
+}
+
+// DefaultConversion returns the policy used wherever no project policy is given: by ToHours, the Activity
+// scheduling and cost methods, and the resource rate setters and builders. It is calendar time, so "1 day" means
+// the same 24 hours when a rate is priced and when the activity is scheduled.
+func DefaultConversion() DurationConversion {
+	return CalendarTimeConversion()
+}
+
+// CalendarTimeConversion returns the calendar-time policy: 24h days, 7-day weeks, 30-day months, 365-day years.
+func CalendarTimeConversion() DurationConversion {
+	return DurationConversion{
+		Basis:         TimeBasisCalendar,
+		HoursPerDay:   CalendarHoursPerDay,
+		HoursPerWeek:  CalendarHoursPerDay * 7,
+		HoursPerMonth: CalendarHoursPerDay * 30,
+		HoursPerYear:  CalendarHoursPerDay * 365,
+	}
+}
+
+// WorkingTimeConversion returns a working-time policy with the given hours per day and working days per week.
+// A month is 52/12 working weeks and a year 52 working weeks.
+// Example: WorkingTimeConversion(8, 5) gives 8h days, 40h weeks, ~173h months, 2080h years.
+func WorkingTimeConversion(hoursPerDay, daysPerWeek float64) DurationConversion {
+	week := hoursPerDay * daysPerWeek
+	return DurationConversion{
+		Basis:         TimeBasisWorking,
+		HoursPerDay:   hoursPerDay,
+		HoursPerWeek:  week,
+		HoursPerMonth: week * 52 / 12,
+		HoursPerYear:  week * 52,
+	}
+}
+
+// Hours converts value (expressed in u) to hours.
+func (c DurationConversion) Hours(value float64, u DurationUnit) float64 {
+	switch u {
+	case DurationUnitMinute:
+		return value / 60
+	case DurationUnitHour:
+		return value
+	case DurationUnitDay:
+		return value * c.HoursPerDay
+	case DurationUnitWeek:
+		return value * c.HoursPerWeek
+	case DurationUnitMonth:
+		return value * c.HoursPerMonth
+	case DurationUnitYear:
+		return value * c.HoursPerYear
+	default:
+		return value
+	}
+}
+
+// Days converts hours to days of HoursPerDay hours. Returns 0 if HoursPerDay is not positive.
+func (c DurationConversion) Days(hours float64) float64 {
+	if c.HoursPerDay <= 0 {
+		return 0
+	}
+	return hours / c.HoursPerDay
+}
@@ -3,6 +3,9 @@ package unit
 
 import "time"
 
+// dateLayout is the format of dates in output and in project files.
+const dateLayout = "2006-01-02"
+
 // Date wraps time.Time for activity start/end dates.
 type Date struct {
 	Time time.Time
@@ -13,12 +16,46 @@ func NewDate(year int, month time.Month, day int) Date {
 	return Date{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
 }
 
+// ParseDate parses a date in YYYY-MM-DD format.
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{Time: t}, nil
+}
+
 // AddHours adds hours to the date.
 func (d Date) AddHours(hours float64) Date {
 	return Date{Time: d.Time.Add(time.Duration(hours * float64(time.Hour)))}
 }
 
+// AddDays adds calendar days to the date.
+func (d Date) AddDays(days int) Date {
+	return Date{Time: d.Time.AddDate(0, 0, days)}
+}
+
+// StartOfDay returns the date at midnight.
+func (d Date) StartOfDay() Date {
+	return NewDate(d.Time.Year(), d.Time.Month(), d.Time.Day())
+}
+
 // String returns the date in YYYY-MM-DD format.
 func (d Date) String() string {
-	return d.Time.Format("2006-01-02")
+	return d.Time.Format(dateLayout)
+}
+
+// MarshalText encodes the date as YYYY-MM-DD (used by JSON and YAML).
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes a date in YYYY-MM-DD format.
+func (d *Date) UnmarshalText(text []byte) error {
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
 }
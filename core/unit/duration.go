@@ -16,7 +16,8 @@ const (
 )
 
 const (
-	WorkingHoursPerDay float64 = 8.0
+	WorkingHoursPerDay  float64 = 8.0
+	CalendarHoursPerDay float64 = 24.0 // Hours in a day as used by ToHours
 )
 
 // Duration represents a time interval (value plus unit).
@@ -47,26 +48,16 @@ func (d *Duration) SetUnit(unit DurationUnit) *Duration {
 	return d
 }
 
-// ToHours returns the duration in calendar hours for comparison and aggregation.
-// For example, "1 day" is converted to 24 hours, not working hours. See WorkingHoursPerDay for working-time conversion if needed.
+// ToHours returns the duration in hours with the default policy (DefaultConversion, calendar time) for comparison
+// and aggregation. For example, "1 day" is converted to 24 hours, not working hours. Use ToHoursWith for working time.
 func (d *Duration) ToHours() float64 {
+	return d.ToHoursWith(DefaultConversion())
+}
+
+// ToHoursWith returns the duration in hours according to the given conversion policy.
+func (d *Duration) ToHoursWith(c DurationConversion) float64 {
 	if d == nil {
 		return 0
 	}
-	switch d.Unit {
-	case DurationUnitMinute:
-		return d.Value / 60
-	case DurationUnitHour:
-		return d.Value
-	case DurationUnitDay:
-		return d.Value * 24
-	case DurationUnitWeek:
-		return d.Value * 24 * 7
-	case DurationUnitMonth:
-		return d.Value * 24 * 30
-	case DurationUnitYear:
-		return d.Value * 24 * 365
-	default:
-		return d.Value
-	}
+	return c.Hours(d.Value, d.Unit)
 }
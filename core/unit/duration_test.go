@@ -50,3 +50,36 @@ func TestNewDuration(t *testing.T) {
 		t.Errorf("NewDuration(2, day).ToHours() = %v, want 48", got)
 	}
 }
+
+func TestDuration_ToHoursWith(t *testing.T) {
+	working := WorkingTimeConversion(8, 5)
+	tests := []struct {
+		value float64
+		u     DurationUnit
+		want  float64
+	}{
+		{90, DurationUnitMinute, 1.5},
+		{3, DurationUnitHour, 3},
+		{1, DurationUnitDay, 8},
+		{2, DurationUnitWeek, 80},
+		{1, DurationUnitYear, 2080},
+	}
+	for _, tt := range tests {
+		d := NewDuration(tt.value, tt.u)
+		if got := d.ToHoursWith(working); got != tt.want {
+			t.Errorf("ToHoursWith(working) %v %s = %v, want %v", tt.value, tt.u, got, tt.want)
+		}
+	}
+	if got := NewDuration(1, DurationUnitMonth).ToHoursWith(CalendarTimeConversion()); got != 720 {
+		t.Errorf("calendar month = %v, want 720", got)
+	}
+}
+
+func TestDurationConversion_Days(t *testing.T) {
+	if got := WorkingTimeConversion(8, 5).Days(20); got != 2.5 {
+		t.Errorf("Days(20) = %v, want 2.5", got)
+	}
+	if got := (DurationConversion{}).Days(20); got != 0 {
+		t.Errorf("Days with zero HoursPerDay = %v, want 0", got)
+	}
+}
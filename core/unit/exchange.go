@@ -0,0 +1,88 @@
+package unit
+
+import "fmt"
+
+// ExchangeRate converts From into To: 1 From = Rate To, effective from Date until a later rate for the same pair.
+type ExchangeRate struct {
+	From string
+	To   string
+	Rate float64
+	Date Date
+}
+
+// ExchangeRates is a table of exchange rates with effective dates.
+type ExchangeRates []ExchangeRate
+
+// direct returns the rate from -> to effective on the given date, looking for the pair in either direction.
+func (t ExchangeRates) direct(from, to string, on Date) (float64, bool) {
+	var found *ExchangeRate
+	inverse := false
+	for i := range t {
+		r := &t[i]
+		if r.Rate <= 0 || r.Date.Time.After(on.Time) {
+			continue
+		}
+		matches := r.From == from && r.To == to
+		inverted := r.From == to && r.To == from
+		if !matches && !inverted {
+			continue
+		}
+		if found == nil || r.Date.Time.After(found.Date.Time) {
+			found, inverse = r, inverted
+		}
+	}
+	if found == nil {
+		return 0, false
+	}
+	if inverse {
+		return 1 / found.Rate, true
+	}
+	return found.Rate, true
+}
+
+// LatestDate returns the most recent effective date of the table, or the zero date if the table is empty.
+func (t ExchangeRates) LatestDate() Date {
+	var latest Date
+	for _, r := range t {
+		if r.Date.Time.After(latest.Time) {
+			latest = r.Date
+		}
+	}
+	return latest
+}
+
+// Rate returns the rate converting from into to on the given date. A pair can be defined in either direction;
+// if no rate links the two currencies directly, a cross rate through a third currency is used.
+// An empty currency matches any currency (rate 1).
+func (t ExchangeRates) Rate(from, to string, on Date) (float64, error) {
+	if from == to || from == "" || to == "" {
+		return 1, nil
+	}
+	if r, ok := t.direct(from, to, on); ok {
+		return r, nil
+	}
+	seen := make(map[string]bool)
+	for _, r := range t {
+		for _, via := range []string{r.From, r.To} {
+			if seen[via] || via == from || via == to {
+				continue
+			}
+			seen[via] = true
+			if r1, ok := t.direct(from, via, on); ok {
+				if r2, ok := t.direct(via, to, on); ok {
+					return r1 * r2, nil
+				}
+			}
+		}
+	}
+	return 0, fmt.Errorf("no exchange rate from %s to %s on %s", from, to, on.String())
+}
+
+// Convert returns the price converted into the given currency with the rates effective on the given date.
+func (t ExchangeRates) Convert(p Price, to string, on Date) (Price, error) {
+	r, err := t.Rate(p.Currency, to, on)
+	if err != nil {
+		return Price{}, err
+	}
+	return Price{Value: p.Value * r, Currency: to}, nil
+}
@@ -0,0 +1,55 @@
+package unit
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestExchangeRates_Rate(t *testing.T) {
+	rates := ExchangeRates{
+		{From: "CHF", To: "EUR", Rate: 1.05, Date: NewDate(2026, time.January, 1)},
+		{From: "CHF", To: "EUR", Rate: 1.10, Date: NewDate(2026, time.April, 1)},
+		{From: "EUR", To: "USD", Rate: 1.20, Date: NewDate(2026, time.January, 1)},
+	}
+	tests := []struct {
+		name     string
+		from, to string
+		on       Date
+		want     float64
+	}{
+		{"same currency", "EUR", "EUR", NewDate(2025, time.June, 1), 1},
+		{"rate in effect", "CHF", "EUR", NewDate(2026, time.March, 31), 1.05},
+		{"later rate", "CHF", "EUR", NewDate(2026, time.April, 1), 1.10},
+		{"inverse", "EUR", "CHF", NewDate(2026, time.February, 1), 1 / 1.05},
+		{"cross rate", "CHF", "USD", NewDate(2026, time.February, 1), 1.05 * 1.20},
+	}
+	for _, tt := range tests {
+		got, err := rates.Rate(tt.from, tt.to, tt.on)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("%s: rate = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := rates.Rate("CHF", "EUR", NewDate(2025, time.December, 31)); err == nil {
+		t.Error("expected an error before the first effective date")
+	}
+	if _, err := rates.Rate("GBP", "EUR", NewDate(2026, time.May, 1)); err == nil {
+		t.Error("expected an error for an unknown currency")
+	}
+}
+
+func TestExchangeRates_Convert(t *testing.T) {
+	rates := ExchangeRates{{From: "CHF", To: "EUR", Rate: 1.05, Date: NewDate(2026, time.January, 1)}}
+	p, err := rates.Convert(*NewPrice(200, "CHF"), "EUR", NewDate(2026, time.March, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(p.Value-210) > 1e-9 || p.Currency != "EUR" {
+		t.Errorf("converted = %v, want 210 EUR", p.String())
+	}
+}
@@ -29,3 +29,8 @@ func (m *MeasurableQuantity) SetUnit(unit MeasurableUnit) *MeasurableQuantity {
 	m.Unit = unit
 	return m
 }
+
+// In returns the quantity converted to the unit u (see ConvertUnit).
+func (m *MeasurableQuantity) In(u MeasurableUnit) (float64, error) {
+	return ConvertUnit(m.Value, m.Unit, u)
+}
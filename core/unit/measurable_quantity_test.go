@@ -1,6 +1,9 @@
 package unit
 
-import "testing"
+import (
+	"math"
+	"testing"
+)
 
 func TestNewMeasurableQuantity(t *testing.T) {
 	q := NewMeasurableQuantity(5, UnitKilogram)
@@ -52,3 +55,65 @@ func TestMeasurableQuantity_SetUnit(t *testing.T) {
 		t.Errorf("SetUnit(kg): Unit = %v, want kg", q.Unit)
 	}
 }
+
+func TestConvertUnit(t *testing.T) {
+	// Compared within rounding: imperial factors are not exact in float64.
+	tests := []struct {
+		value    float64
+		from, to MeasurableUnit
+		want     float64
+	}{
+		{500, UnitGram, UnitKilogram, 0.5},
+		{2.5, UnitTon, UnitKilogram, 2500},
+		{150, UnitCentimeter, UnitMeter, 1.5},
+		{1, UnitSquareMeter, UnitSquareCentimeter, 10000},
+		{250, UnitCubicDecimeter, UnitCubicMeter, 0.25},
+		{3, UnitMeter, UnitMeter, 3},
+		{2, UnitLiter, UnitMilliliter, 2000},
+		{1, UnitCubicMeter, UnitLiter, 1000},
+		{10, UnitFoot, UnitMeter, 3.048},
+		{1, UnitSquareMeter, UnitSquareFoot, 1e6 / 92903.04},
+		{100, UnitPound, UnitKilogram, 45.359237},
+		{1, UnitGallon, UnitLiter, 3.785411784},
+		{12, UnitPiece, UnitPiece, 12},
+	}
+	for _, tt := range tests {
+		got, err := ConvertUnit(tt.value, tt.from, tt.to)
+		if err != nil || math.Abs(got-tt.want) > 1e-12*math.Max(1, tt.want) {
+			t.Errorf("ConvertUnit(%v %s → %s) = %v, %v; want %v", tt.value, tt.from, tt.to, got, err, tt.want)
+		}
+	}
+	if _, err := ConvertUnit(1, UnitSquareMeter, UnitKilogram); err == nil {
+		t.Error("expected an error converting area to mass")
+	}
+	if _, err := ConvertUnit(1, "bag", UnitKilogram); err == nil {
+		t.Error("expected an error for an unknown unit")
+	}
+}
+
+func TestMeasurableUnit_Dimension(t *testing.T) {
+	if UnitCubicMeter.Dimension() != DimensionVolume || UnitTon.Dimension() != DimensionMass {
+		t.Errorf("Dimension = %s, %s", UnitCubicMeter.Dimension(), UnitTon.Dimension())
+	}
+	if !UnitGram.Compatible(UnitKilogram) || UnitMeter.Compatible(UnitSquareMeter) {
+		t.Error("Compatible: g/kg should match, m/m² should not")
+	}
+}
+
+func TestUnitsOf(t *testing.T) {
+	got := UnitsOf(DimensionVolume)
+	want := []MeasurableUnit{UnitCubicMillimeter, UnitCubicCentimeter, UnitMilliliter, UnitCubicDecimeter, UnitLiter, UnitGallon, UnitCubicMeter, UnitCubicKilometer}
+	if len(got) != len(want) {
+		t.Fatalf("UnitsOf(volume) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("UnitsOf(volume) = %v, want %v", got, want)
+		}
+	}
+	for _, d := range Dimensions {
+		if len(UnitsOf(d)) == 0 {
+			t.Errorf("no units for dimension %s", d)
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package unit
 
-// MeasurableUnit is the unit of measure (length, area, volume, weight: mm, m, m², kg, ...).
+import (
+	"fmt"
+	"sort"
+)
+
+// MeasurableUnit is the unit of measure (length, area, volume, weight, count: mm, m, m², l, kg, lb, pc, ...).
 type MeasurableUnit string
 
 const (
@@ -10,18 +15,23 @@ const (
 	UnitDecimeter  MeasurableUnit = "dm"
 	UnitMeter      MeasurableUnit = "m"
 	UnitKilometer  MeasurableUnit = "km"
+	UnitFoot       MeasurableUnit = "ft" // Linear foot
 	// Unit of measurement for area
 	UnitSquareMillimeter MeasurableUnit = "mm²"
 	UnitSquareCentimeter MeasurableUnit = "cm²"
 	UnitSquareDecimeter  MeasurableUnit = "dm²"
 	UnitSquareMeter      MeasurableUnit = "m²"
 	UnitSquareKilometer  MeasurableUnit = "km²"
+	UnitSquareFoot       MeasurableUnit = "ft²"
 	// Unit of measurement for volume
 	UnitCubicMillimeter MeasurableUnit = "mm³"
 	UnitCubicCentimeter MeasurableUnit = "cm³"
 	UnitCubicDecimeter  MeasurableUnit = "dm³"
 	UnitCubicMeter      MeasurableUnit = "m³"
 	UnitCubicKilometer  MeasurableUnit = "km³"
+	UnitMilliliter      MeasurableUnit = "ml"
+	UnitLiter           MeasurableUnit = "l"
+	UnitGallon          MeasurableUnit = "gal" // US liquid gallon
 	// Unit of measurement for weight
 	UnitMilligram MeasurableUnit = "mg"
 	UnitCentigram MeasurableUnit = "cg"
@@ -29,4 +39,121 @@ const (
 	UnitGram      MeasurableUnit = "g"
 	UnitKilogram  MeasurableUnit = "kg"
 	UnitTon       MeasurableUnit = "t"
+	UnitPound     MeasurableUnit = "lb"
+	// Unit of measurement for count
+	UnitPiece MeasurableUnit = "pc"
 )
+
+// Dimension is the physical dimension of a measurable unit: only units of the same dimension convert into each other.
+type Dimension string
+
+const (
+	DimensionLength Dimension = "length"
+	DimensionArea   Dimension = "area"
+	DimensionVolume Dimension = "volume"
+	DimensionMass   Dimension = "mass"
+	DimensionCount  Dimension = "count"
+)
+
+// Dimensions lists the dimensions in display order.
+var Dimensions = []Dimension{DimensionLength, DimensionArea, DimensionVolume, DimensionMass, DimensionCount}
+
+// unitScale is the dimension of a unit and its size in the smallest metric unit of the dimension (mm, mm², mm³, mg).
+// Metric sizes are powers of ten, exact in float64, so metric conversions are correctly rounded.
+// Imperial sizes use the international definitions: 1 ft = 0.3048 m, 1 lb = 0.45359237 kg, 1 US gal = 231 in³ = 3.785411784 l.
+type unitScale struct {
+	dimension Dimension
+	size      float64
+}
+
+var unitScales = map[MeasurableUnit]unitScale{
+	UnitMillimeter: {DimensionLength, 1},
+	UnitCentimeter: {DimensionLength, 1e1},
+	UnitDecimeter:  {DimensionLength, 1e2},
+	UnitMeter:      {DimensionLength, 1e3},
+	UnitKilometer:  {DimensionLength, 1e6},
+	UnitFoot:       {DimensionLength, 304.8},
+
+	UnitSquareMillimeter: {DimensionArea, 1},
+	UnitSquareCentimeter: {DimensionArea, 1e2},
+	UnitSquareDecimeter:  {DimensionArea, 1e4},
+	UnitSquareMeter:      {DimensionArea, 1e6},
+	UnitSquareKilometer:  {DimensionArea, 1e12},
+	UnitSquareFoot:       {DimensionArea, 92903.04},
+
+	UnitCubicMillimeter: {DimensionVolume, 1},
+	UnitCubicCentimeter: {DimensionVolume, 1e3},
+	UnitCubicDecimeter:  {DimensionVolume, 1e6},
+	UnitCubicMeter:      {DimensionVolume, 1e9},
+	UnitCubicKilometer:  {DimensionVolume, 1e18},
+	UnitMilliliter:      {DimensionVolume, 1e3},
+	UnitLiter:           {DimensionVolume, 1e6},
+	UnitGallon:          {DimensionVolume, 3785411.784},
+
+	UnitMilligram: {DimensionMass, 1},
+	UnitCentigram: {DimensionMass, 1e1},
+	UnitDecigram:  {DimensionMass, 1e2},
+	UnitGram:      {DimensionMass, 1e3},
+	UnitKilogram:  {DimensionMass, 1e6},
+	UnitTon:       {DimensionMass, 1e9},
+	UnitPound:     {DimensionMass, 453592.37},
+
+	UnitPiece: {DimensionCount, 1},
+}
+
+// UnitsOf returns the known units of the dimension, from the smallest to the largest.
+func UnitsOf(d Dimension) []MeasurableUnit {
+	var units []MeasurableUnit
+	for u, s := range unitScales {
+		if s.dimension == d {
+			units = append(units, u)
+		}
+	}
+	sort.Slice(units, func(i, j int) bool {
+		si, sj := unitScales[units[i]].size, unitScales[units[j]].size
+		if si != sj {
+			return si < sj
+		}
+		return units[i] < units[j]
+	})
+	return units
+}
+
+// IsValid returns true if u is a known unit.
+func (u MeasurableUnit) IsValid() bool {
+	_, ok := unitScales[u]
+	return ok
+}
+
+// Dimension returns the dimension of the unit ("" if the unit is unknown).
+func (u MeasurableUnit) Dimension() Dimension {
+	return unitScales[u].dimension
+}
+
+// Compatible returns true if values in u convert into v (same unit, or known units of the same dimension).
+func (u MeasurableUnit) Compatible(v MeasurableUnit) bool {
+	return u == v || (u.IsValid() && u.Dimension() == v.Dimension())
+}
+
+// ConvertUnit converts value from one unit to another of the same dimension (e.g. 500 g → 0.5 kg).
+// Converting a unit to itself returns the value unchanged, even for unknown units.
+func ConvertUnit(value float64, from, to MeasurableUnit) (float64, error) {
+	if from == to {
+		return value, nil
+	}
+	f, okFrom := unitScales[from]
+	t, okTo := unitScales[to]
+	switch {
+	case !okFrom:
+		return 0, fmt.Errorf("unknown unit %q", from)
+	case !okTo:
+		return 0, fmt.Errorf("unknown unit %q", to)
+	case f.dimension != t.dimension:
+		return 0, fmt.Errorf("cannot convert %s (%s) to %s (%s)", from, f.dimension, to, t.dimension)
+	}
+	// Multiply or divide by the exact ratio so that e.g. 500 g is exactly 0.5 kg.
+	if f.size >= t.size {
+		return value * (f.size / t.size), nil
+	}
+	return value / (t.size / f.size), nil
+}
@@ -0,0 +1,266 @@
+package unit
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// MoneyScale is the number of Money amount units in one currency unit: amounts are exact to 4 decimals,
+// so unit prices below the minor unit (e.g. 0.0125 EUR per screw) are kept without rounding.
+const MoneyScale = 10000
+
+// moneyDecimals is the number of decimals of MoneyScale.
+const moneyDecimals = 4
+
+// RoundingMode defines how an amount is rounded when it cannot be represented exactly.
+type RoundingMode int
+
+const (
+	RoundHalfEven RoundingMode = iota // To the nearest, ties to even (banker's rounding, default)
+	RoundHalfUp                       // To the nearest, ties away from zero
+	RoundDown                         // Toward zero (truncate)
+	RoundUp                           // Away from zero
+)
+
+// minorUnits lists the currencies whose minor unit is not 2 decimals (ISO 4217).
+var minorUnits = map[string]int{
+	"JPY": 0, "KRW": 0, "CLP": 0, "ISK": 0, "VND": 0, "XAF": 0, "XOF": 0,
+	"BHD": 3, "KWD": 3, "OMR": 3, "JOD": 3, "TND": 3, "LYD": 3, "IQD": 3,
+}
+
+// MinorUnits returns the number of decimals of the currency's minor unit (2 if not listed, e.g. EUR cents).
+func MinorUnits(currency string) int {
+	if d, ok := minorUnits[strings.ToUpper(currency)]; ok {
+		return d
+	}
+	return 2
+}
+
+// Money is an exact amount of a currency: Amount counts 1/MoneyScale of the currency unit.
+// Sums are exact; multiplications and divisions round to 4 decimals with an explicit RoundingMode,
+// and Round rounds to the currency's minor unit (e.g. cents) for quotes and invoices.
+// Amounts in different currencies are not converted (see ExchangeRates).
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// NewMoney returns the amount closest to value (the shortest decimal representation of the float), rounded half-even to 4 decimals.
+func NewMoney(value float64, currency string) Money {
+	m, _ := ParseMoney(strconv.FormatFloat(value, 'f', -1, 64), currency)
+	return m
+}
+
+// ParseMoney parses a decimal string (e.g. "12.345", "-0.5") exactly; digits beyond 4 decimals are rounded half-even.
+func ParseMoney(s string, currency string) (Money, error) {
+	num, den, err := parseDecimal(s)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount %q: %w", s, err)
+	}
+	num.Mul(num, big.NewInt(MoneyScale))
+	q := divRoundBig(num, den, RoundHalfEven)
+	if !q.IsInt64() {
+		return Money{}, fmt.Errorf("invalid amount %q: out of range", s)
+	}
+	return Money{Amount: q.Int64(), Currency: currency}, nil
+}
+
+// parseDecimal returns the decimal string as the fraction num/den (den a power of ten).
+func parseDecimal(s string) (*big.Int, *big.Int, error) {
+	s = strings.TrimSpace(s)
+	digits := s
+	decimals := 0
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		digits = s[:i] + s[i+1:]
+		decimals = len(s) - i - 1
+	}
+	num, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, nil, fmt.Errorf("not a decimal number")
+	}
+	den := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	return num, den, nil
+}
+
+// divRound returns num/den rounded to an integer with the given mode, saturated to the int64 range.
+func divRound(num, den *big.Int, mode RoundingMode) int64 {
+	q := divRoundBig(num, den, mode)
+	switch {
+	case q.IsInt64():
+		return q.Int64()
+	case q.Sign() > 0:
+		return math.MaxInt64
+	}
+	return math.MinInt64
+}
+
+// divRoundBig returns num/den rounded to an integer with the given mode.
+func divRoundBig(num, den *big.Int, mode RoundingMode) *big.Int {
+	if den.Sign() < 0 {
+		num, den = new(big.Int).Neg(num), new(big.Int).Neg(den)
+	}
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	away := false
+	switch mode {
+	case RoundDown:
+	case RoundUp:
+		away = true
+	default:
+		// Compare twice the remainder with the divisor to find the nearest.
+		twice := new(big.Int).Abs(r)
+		twice.Lsh(twice, 1)
+		switch twice.Cmp(den) {
+		case 1:
+			away = true
+		case 0:
+			away = mode == RoundHalfUp || q.Bit(0) == 1
+		}
+	}
+	if away {
+		if num.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// Float64 returns the amount as a float (for display and for the float-based APIs).
+func (m Money) Float64() float64 {
+	return float64(m.Amount) / MoneyScale
+}
+
+// IsZero returns true if the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// currencyWith returns the currency of a result combining m and o: the first non-empty one.
+func (m Money) currencyWith(o Money) string {
+	if m.Currency != "" {
+		return m.Currency
+	}
+	return o.Currency
+}
+
+// Add returns m + o (exact). The currency is m's, or o's if m has none.
+func (m Money) Add(o Money) Money {
+	return Money{Amount: m.Amount + o.Amount, Currency: m.currencyWith(o)}
+}
+
+// Sub returns m - o (exact).
+func (m Money) Sub(o Money) Money {
+	return Money{Amount: m.Amount - o.Amount, Currency: m.currencyWith(o)}
+}
+
+// MulInt returns m × n (exact).
+func (m Money) MulInt(n int64) Money {
+	return Money{Amount: m.Amount * n, Currency: m.Currency}
+}
+
+// Mul returns m × q rounded to 4 decimals with the given mode. q is taken as its shortest decimal representation,
+// so quantities such as 2.5 m or 0.1 kg are multiplied exactly. A NaN or infinite q returns zero, and results
+// beyond the int64 range are saturated.
+func (m Money) Mul(q float64, mode RoundingMode) Money {
+	if !isFinite(q) {
+		return Money{Currency: m.Currency}
+	}
+	num, den, _ := parseDecimal(strconv.FormatFloat(q, 'f', -1, 64))
+	num.Mul(num, big.NewInt(m.Amount))
+	return Money{Amount: divRound(num, den, mode), Currency: m.Currency}
+}
+
+// Div returns m / q rounded to 4 decimals with the given mode; dividing by zero, NaN or infinity returns zero,
+// and results beyond the int64 range are saturated.
+func (m Money) Div(q float64, mode RoundingMode) Money {
+	if !isFinite(q) {
+		return Money{Currency: m.Currency}
+	}
+	num, den, _ := parseDecimal(strconv.FormatFloat(q, 'f', -1, 64))
+	if num.Sign() == 0 {
+		return Money{Currency: m.Currency}
+	}
+	den.Mul(den, big.NewInt(m.Amount))
+	return Money{Amount: divRound(den, num, mode), Currency: m.Currency}
+}
+
+// isFinite returns true if q is neither NaN nor infinite.
+func isFinite(q float64) bool {
+	return !math.IsNaN(q) && !math.IsInf(q, 0)
+}
+
+// Round returns the amount rounded to the currency's minor unit (e.g. cents for EUR) with the given mode.
+func (m Money) Round(mode RoundingMode) Money {
+	step := int64(1)
+	for i := MinorUnits(m.Currency); i < moneyDecimals; i++ {
+		step *= 10
+	}
+	return Money{Amount: divRound(big.NewInt(m.Amount), big.NewInt(step), mode) * step, Currency: m.Currency}
+}
+
+// Decimal returns the amount as a decimal string with the given number of decimals (0 to 4), rounded half-even.
+func (m Money) Decimal(decimals int) string {
+	if decimals < 0 {
+		decimals = 0
+	}
+	if decimals > moneyDecimals {
+		decimals = moneyDecimals
+	}
+	step := int64(1)
+	for i := decimals; i < moneyDecimals; i++ {
+		step *= 10
+	}
+	v := divRound(big.NewInt(m.Amount), big.NewInt(step), RoundHalfEven)
+	sign := ""
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	s := strconv.FormatInt(v, 10)
+	if decimals == 0 {
+		return sign + s
+	}
+	for len(s) <= decimals {
+		s = "0" + s
+	}
+	return sign + s[:len(s)-decimals] + "." + s[len(s)-decimals:]
+}
+
+// String formats the amount rounded half-even to the currency's minor unit (e.g. "10.50 EUR").
+func (m Money) String() string {
+	return strings.TrimSpace(m.Decimal(MinorUnits(m.Currency)) + " " + m.Currency)
+}
+
+// MarshalText encodes the exact amount and currency (e.g. "10.0125 EUR"), without trailing zeros.
+func (m Money) MarshalText() ([]byte, error) {
+	s := m.Decimal(moneyDecimals)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "" || s == "-" {
+		s = "0"
+	}
+	return []byte(strings.TrimSpace(s + " " + m.Currency)), nil
+}
+
+// UnmarshalText decodes an amount with an optional currency (e.g. "10.50 EUR", "3").
+func (m *Money) UnmarshalText(text []byte) error {
+	fields := strings.Fields(string(text))
+	if len(fields) == 0 || len(fields) > 2 {
+		return fmt.Errorf("invalid money %q", string(text))
+	}
+	currency := ""
+	if len(fields) == 2 {
+		currency = fields[1]
+	}
+	parsed, err := ParseMoney(fields[0], currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
@@ -0,0 +1,135 @@
+package unit
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestNewMoney(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  int64
+	}{
+		{10.5, 105000},
+		{0.1, 1000},
+		{0.0125, 125},
+		{0.00005, 0}, // Tie: half-even rounds to 0
+		{0.00015, 2}, // Tie: half-even rounds to 2
+		{-1.23456, -12346},
+	}
+	for _, tt := range tests {
+		if got := NewMoney(tt.value, "EUR"); got.Amount != tt.want || got.Currency != "EUR" {
+			t.Errorf("NewMoney(%v) = %+v, want amount %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	m, err := ParseMoney("12.345", "EUR")
+	if err != nil || m.Amount != 123450 {
+		t.Errorf("ParseMoney(12.345) = %+v, %v", m, err)
+	}
+	if _, err := ParseMoney("12,5", "EUR"); err == nil {
+		t.Error("expected an error for a comma decimal separator")
+	}
+}
+
+func TestMoney_SumIsExact(t *testing.T) {
+	var total Money
+	for i := 0; i < 1000; i++ {
+		total = total.Add(NewMoney(0.1, "EUR"))
+	}
+	if total.Float64() != 100 {
+		t.Errorf("sum of 1000 × 0.1 = %v, want exactly 100", total.Float64())
+	}
+}
+
+func TestMoney_MulDiv(t *testing.T) {
+	m := NewMoney(10, "EUR")
+	if got := m.Div(3, RoundHalfEven); got.Amount != 33333 {
+		t.Errorf("10 / 3 = %v, want 3.3333", got.Amount)
+	}
+	if got := m.Div(3, RoundUp); got.Amount != 33334 {
+		t.Errorf("10 / 3 rounded up = %v, want 3.3334", got.Amount)
+	}
+	if got := NewMoney(2.15, "EUR").Mul(2.5, RoundHalfEven); got.Amount != 53750 {
+		t.Errorf("2.15 × 2.5 = %v, want 5.375", got.Amount)
+	}
+	if got := m.Div(0, RoundHalfEven); !got.IsZero() || got.Currency != "EUR" {
+		t.Errorf("10 / 0 = %+v, want 0 EUR", got)
+	}
+	if got := NewMoney(1.5, "EUR").MulInt(3); got.Amount != 45000 {
+		t.Errorf("1.5 × 3 = %v, want 4.5", got.Amount)
+	}
+}
+
+func TestMoney_MulDivNotFinite(t *testing.T) {
+	m := NewMoney(10, "EUR")
+	for _, q := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if got := m.Mul(q, RoundHalfEven); !got.IsZero() || got.Currency != "EUR" {
+			t.Errorf("10 × %v = %+v, want 0 EUR", q, got)
+		}
+		if got := m.Div(q, RoundHalfEven); !got.IsZero() || got.Currency != "EUR" {
+			t.Errorf("10 / %v = %+v, want 0 EUR", q, got)
+		}
+	}
+}
+
+func TestMoney_Overflow(t *testing.T) {
+	if got := NewMoney(1e12, "EUR").Mul(1e8, RoundHalfEven); got.Amount != math.MaxInt64 {
+		t.Errorf("1e12 × 1e8 = %v, want saturated to MaxInt64", got.Amount)
+	}
+	if got := NewMoney(-1e12, "EUR").Div(1e-8, RoundHalfEven); got.Amount != math.MinInt64 {
+		t.Errorf("-1e12 / 1e-8 = %v, want saturated to MinInt64", got.Amount)
+	}
+	if _, err := ParseMoney("1e30", "EUR"); err == nil {
+		t.Error("ParseMoney(1e30) should fail")
+	}
+	if _, err := ParseMoney("10000000000000000000", "EUR"); err == nil {
+		t.Error("ParseMoney beyond the int64 range should fail")
+	}
+}
+
+func TestMoney_Round(t *testing.T) {
+	tests := []struct {
+		value    float64
+		currency string
+		mode     RoundingMode
+		want     string
+	}{
+		{2.345, "EUR", RoundHalfEven, "2.34 EUR"},
+		{2.345, "EUR", RoundHalfUp, "2.35 EUR"},
+		{2.355, "EUR", RoundHalfEven, "2.36 EUR"},
+		{2.349, "EUR", RoundDown, "2.34 EUR"},
+		{2.341, "EUR", RoundUp, "2.35 EUR"},
+		{-2.345, "EUR", RoundHalfUp, "-2.35 EUR"},
+		{1234.5, "JPY", RoundHalfEven, "1234 JPY"},
+		{1.2345, "KWD", RoundHalfUp, "1.235 KWD"},
+	}
+	for _, tt := range tests {
+		if got := NewMoney(tt.value, tt.currency).Round(tt.mode).String(); got != tt.want {
+			t.Errorf("Round(%v %s, %d) = %q, want %q", tt.value, tt.currency, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestMinorUnits(t *testing.T) {
+	if MinorUnits("EUR") != 2 || MinorUnits("jpy") != 0 || MinorUnits("BHD") != 3 {
+		t.Errorf("MinorUnits = EUR %d, JPY %d, BHD %d", MinorUnits("EUR"), MinorUnits("jpy"), MinorUnits("BHD"))
+	}
+}
+
+func TestMoney_Text(t *testing.T) {
+	data, err := json.Marshal(NewMoney(10.0125, "EUR"))
+	if err != nil || string(data) != `"10.0125 EUR"` {
+		t.Fatalf("Marshal = %s, %v", data, err)
+	}
+	var m Money
+	if err := json.Unmarshal(data, &m); err != nil || m.Amount != 100125 || m.Currency != "EUR" {
+		t.Errorf("Unmarshal = %+v, %v", m, err)
+	}
+	if err := m.UnmarshalText([]byte("abc EUR")); err == nil {
+		t.Error("expected an error for an invalid amount")
+	}
+}
@@ -1,6 +1,9 @@
 package unit
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // Price represents an amount with currency.
 type Price struct {
@@ -17,3 +20,26 @@ func NewPrice(value float64, currency string) *Price {
 func (p *Price) String() string {
 	return fmt.Sprintf("%.2f %s", p.Value, p.Currency)
 }
+
+// Money returns the price as an exact Money amount (Value rounded half-even to 4 decimals).
+func (p Price) Money() Money {
+	return NewMoney(p.Value, p.Currency)
+}
+
+// Price returns the amount as a Price (Value is the float closest to the exact amount).
+func (m Money) Price() Price {
+	return Price{Value: m.Float64(), Currency: m.Currency}
+}
+
+// priceFields is Price without its methods (avoids recursion when encoding).
+type priceFields Price
+
+// MarshalJSON encodes Value rounded to the Money scale, so float artefacts (e.g. 0.30000000000000004) are not saved.
+func (p Price) MarshalJSON() ([]byte, error) {
+	return json.Marshal(priceFields(p.Money().Price()))
+}
+
+// MarshalYAML encodes Value rounded to the Money scale (see MarshalJSON).
+func (p Price) MarshalYAML() (interface{}, error) {
+	return priceFields(p.Money().Price()), nil
+}
@@ -1,6 +1,9 @@
 package unit
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
+)
 
 func TestNewPrice(t *testing.T) {
 	p := NewPrice(10.50, "EUR")
@@ -19,3 +22,14 @@ func TestPrice_String(t *testing.T) {
 		t.Errorf("String() = %q, want \"10.50 EUR\"", got)
 	}
 }
+
+func TestPrice_MarshalJSON(t *testing.T) {
+	p := Price{Value: 0.1 + 0.2, Currency: "EUR"}
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"Value":0.3,"Currency":"EUR"}` {
+		t.Errorf("Marshal = %s, want Value 0.3", data)
+	}
+}
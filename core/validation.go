@@ -42,6 +42,13 @@ func (r *ValidationResult) AddWarning(activity, msg string) {
 
 // Validate checks the activity tree for errors and warnings.
 func (a *Activity) Validate() *ValidationResult {
+	r := a.validateTree()
+	a.checkCurrencies(r)
+	return r
+}
+
+// validateTree runs the checks of Validate except the currency check, which depends on the project exchange rates.
+func (a *Activity) validateTree() *ValidationResult {
 	r := &ValidationResult{}
 	all := make(map[*Activity]bool)
 	collectActivities(a, all)
@@ -54,38 +61,140 @@ func (a *Activity) Validate() *ValidationResult {
 	// Check that DependsOn references exist in the tree
 	for _, act := range allActivities(a) {
 		for _, dep := range act.DependsOn {
-			if !all[dep] {
-				r.AddError(act.Name, fmt.Sprintf("DependsOn references activity %q not in tree", dep.Name))
+			if dep.Activity == nil {
+				r.AddError(act.Name, "DependsOn has a nil activity")
+				continue
+			}
+			if !all[dep.Activity] {
+				r.AddError(act.Name, fmt.Sprintf("DependsOn references activity %q not in tree", dep.Activity.Name))
+			}
+			if !IsValidDependencyType(dep.Type) {
+				r.AddError(act.Name, fmt.Sprintf("unknown dependency type %q on %q", dep.Type, dep.Activity.Name))
 			}
 		}
 	}
 
-	// Warnings: activities without materials/resources
+	// Check constraints: known type, date set when required
 	for _, act := range allActivities(a) {
-		if len(act.ComplexMaterials) == 0 && len(act.CountableMaterials) == 0 && len(act.MeasurableMaterials) == 0 &&
-			len(act.HumanResources) == 0 && len(act.Assets) == 0 && len(act.Activities) > 0 {
-			r.AddWarning(act.Name, "activity has sub-activities but no materials or resources")
+		c := act.Constraint
+		if c == nil {
+			continue
+		}
+		if !IsValidConstraintType(c.Type) {
+			r.AddError(act.Name, fmt.Sprintf("unknown constraint type %q", c.Type))
+		} else if c.Type.needsDate() && c.Date == nil {
+			r.AddError(act.Name, fmt.Sprintf("constraint %s requires a date", c.Type))
 		}
 	}
 
-	// Check currency consistency (all same currency)
-	currencies := make(map[string]bool)
+	// Check three-point estimates: 0 <= optimistic <= most likely <= pessimistic, known distribution
 	for _, act := range allActivities(a) {
-		if act.Price.Currency != "" {
-			currencies[act.Price.Currency] = true
+		if act.Estimate != nil && !act.Estimate.valid() {
+			r.AddError(act.Name, "three-point estimate must satisfy 0 <= optimistic <= most likely <= pessimistic with a known distribution")
+		}
+		if act.CostEstimate != nil && !act.CostEstimate.valid() {
+			r.AddError(act.Name, "cost estimate must satisfy 0 <= optimistic <= most likely <= pessimistic with a known distribution")
 		}
 	}
-	if len(currencies) > 1 {
-		var list []string
-		for c := range currencies {
-			list = append(list, c)
+
+	// Check leveling delays
+	for _, act := range allActivities(a) {
+		if act.LevelingDelay != nil && act.LevelingDelay.Value < 0 {
+			r.AddError(act.Name, "leveling delay cannot be negative")
+		}
+	}
+
+	// Check progress: percent in range, finish after start, non-negative remaining work and actual cost
+	for _, act := range allActivities(a) {
+		if act.PercentComplete < 0 || act.PercentComplete > 100 {
+			r.AddError(act.Name, fmt.Sprintf("percent complete %.0f must be between 0 and 100", act.PercentComplete))
+		}
+		if act.ActualFinish != nil && act.ActualStart == nil {
+			r.AddError(act.Name, "actual finish requires an actual start")
+		}
+		if act.ActualStart != nil && act.ActualFinish != nil && act.ActualFinish.Time.Before(act.ActualStart.Time) {
+			r.AddError(act.Name, "actual finish is before actual start")
+		}
+		if act.RemainingDuration != nil && act.RemainingDuration.Value < 0 {
+			r.AddError(act.Name, "remaining duration cannot be negative")
+		}
+		if act.ActualCost < 0 {
+			r.AddError(act.Name, "actual cost cannot be negative")
+		}
+	}
+
+	// Check assignments: resource set, positive units, non-negative work
+	for _, act := range allActivities(a) {
+		for _, as := range act.Assignments {
+			if as.Resource == nil {
+				r.AddError(act.Name, "assignment has a nil resource")
+				continue
+			}
+			if as.Units <= 0 {
+				r.AddError(act.Name, fmt.Sprintf("assignment of %q must have positive units", as.Resource.Name))
+			}
+			if as.WorkHours < 0 {
+				r.AddError(act.Name, fmt.Sprintf("assignment of %q has negative work hours", as.Resource.Name))
+			}
+		}
+	}
+
+	// Check materials: non-negative waste and pack size; the measurable quantity must convert to the price unit (same dimension)
+	for _, act := range allActivities(a) {
+		materialError := func(name string, err error) {
+			if err != nil {
+				r.AddError(act.Name, fmt.Sprintf("material %q: %v", name, err))
+			}
+		}
+		for _, m := range act.CountableMaterials {
+			materialError(m.Name, m.CheckPurchase())
+		}
+		for _, m := range act.MeasurableMaterials {
+			materialError(m.Name, m.CheckUnits())
+			materialError(m.Name, m.CheckPurchase())
+		}
+		for _, m := range act.ComplexMaterials {
+			materialError(m.Name, m.CheckPurchase())
+			if m.MeasurableMaterial != nil {
+				materialError(m.Name, m.MeasurableMaterial.CheckUnits())
+				materialError(m.Name, m.MeasurableMaterial.CheckPurchase())
+				if m.MeasurableMaterial.WastePercent != 0 || m.MeasurableMaterial.PackSize != 0 {
+					r.AddWarning(act.Name, fmt.Sprintf("material %q: waste and pack size of the component are ignored, set them on the complex material", m.Name))
+				}
+			}
+		}
+	}
+
+	// Check that activity IDs are unique (DependsOn is persisted by ID)
+	ids := make(map[string]bool)
+	for _, act := range allActivities(a) {
+		if act.ID == "" {
+			continue
+		}
+		if ids[act.ID] {
+			r.AddError(act.Name, fmt.Sprintf("duplicate activity ID %q", act.ID))
+		}
+		ids[act.ID] = true
+	}
+
+	// Warnings: activities without materials/resources
+	for _, act := range allActivities(a) {
+		if len(act.ComplexMaterials) == 0 && len(act.CountableMaterials) == 0 && len(act.MeasurableMaterials) == 0 &&
+			len(act.HumanResources) == 0 && len(act.Assets) == 0 && len(act.Assignments) == 0 && len(act.Activities) > 0 {
+			r.AddWarning(act.Name, "activity has sub-activities but no materials or resources")
 		}
-		r.AddWarning(a.Name, "multiple currencies used: "+strings.Join(list, ", "))
 	}
 
 	return r
 }
 
+// checkCurrencies warns if the tree uses more than one currency: totals add values without conversion.
+func (a *Activity) checkCurrencies(r *ValidationResult) {
+	if list := a.currencies(); len(list) > 1 {
+		r.AddWarning(a.Name, "multiple currencies used: "+strings.Join(list, ", "))
+	}
+}
+
 func collectActivities(a *Activity, m map[*Activity]bool) {
 	m[a] = true
 	for _, child := range a.Activities {
@@ -107,8 +216,8 @@ func hasCycle(a *Activity, all map[*Activity]bool, visiting, visited map[*Activi
 	}
 	visiting[a] = true
 	for _, dep := range a.DependsOn {
-		if all[dep] {
-			if hasCycle(dep, all, visiting, visited) {
+		if all[dep.Activity] {
+			if hasCycle(dep.Activity, all, visiting, visited) {
 				return true
 			}
 		}
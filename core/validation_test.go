@@ -1,7 +1,9 @@
 package core
 
 import (
+	"explosio/core/material"
 	"explosio/core/unit"
+	"strings"
 	"testing"
 )
 
@@ -34,3 +36,52 @@ func TestValidate_CircularDependency(t *testing.T) {
 		t.Error("Expected validation error for circular dependency")
 	}
 }
+
+func TestValidate_DuplicateID(t *testing.T) {
+	root := NewActivity("Root", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	child := NewActivity("Child", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	child.ID = root.ID
+	root.AddActivity(child)
+
+	if r := root.Validate(); r.Valid() {
+		t.Error("Expected validation error for duplicate activity ID")
+	}
+}
+
+func TestValidate_MeasurableUnits(t *testing.T) {
+	root := NewActivity("Root", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	paint := material.NewMeasurableMaterial("Paint", "", *unit.NewPrice(8, "EUR"), *unit.NewMeasurableQuantity(5, unit.UnitKilogram))
+	paint.PriceUnit = unit.UnitSquareMeter
+	root.AddMeasurableMaterial(paint)
+
+	r := root.Validate()
+	if len(r.Errors) != 1 || !strings.Contains(r.Errors[0].Message, "cannot convert kg (mass) to m² (area)") {
+		t.Errorf("errors = %v, want an incompatible dimension error", r.Errors)
+	}
+}
+
+func TestValidate_MaterialWasteAndPack(t *testing.T) {
+	root := NewActivity("Root", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	screws := material.NewCountableMaterial("Screws", "", *unit.NewPrice(0.1, "EUR"), 10)
+	screws.WastePercent = -5
+	root.AddCountableMaterial(screws)
+	cement := material.NewMeasurableMaterial("Cement", "", *unit.NewPrice(0.3, "EUR"), *unit.NewMeasurableQuantity(10, unit.UnitKilogram))
+	cement.PackSize = -25
+	root.AddMeasurableMaterial(cement)
+
+	if r := root.Validate(); len(r.Errors) != 2 {
+		t.Errorf("errors = %v, want negative waste and negative pack size", r.Errors)
+	}
+}
+
+func TestValidate_ComplexComponentWaste(t *testing.T) {
+	root := NewActivity("Root", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(0, "EUR"))
+	pipe := material.NewMeasurableMaterial("Pipe", "", *unit.NewPrice(3, "EUR"), *unit.NewMeasurableQuantity(2, unit.UnitMeter))
+	pipe.WastePercent = 10
+	root.AddComplexMaterial(material.NewComplexMaterial("Pipe kit", "", *unit.NewPrice(0, "EUR"), 5, pipe))
+
+	r := root.Validate()
+	if !r.Valid() || len(r.Warnings) != 1 || !strings.Contains(r.Warnings[0].Message, "ignored") {
+		t.Errorf("errors = %v, warnings = %v, want a warning that the component waste is ignored", r.Errors, r.Warnings)
+	}
+}
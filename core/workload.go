@@ -0,0 +1,326 @@
+package core
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"explosio/core/resource"
+	"explosio/core/unit"
+)
+
+// LoadPeriod is the length of the time buckets of a workload report.
+type LoadPeriod string
+
+const (
+	PeriodDay   LoadPeriod = "day"
+	PeriodWeek  LoadPeriod = "week" // Weeks start on Monday
+	PeriodMonth LoadPeriod = "month"
+)
+
+// IsValidLoadPeriod returns true if p is a known period.
+func IsValidLoadPeriod(p LoadPeriod) bool {
+	switch p {
+	case PeriodDay, PeriodWeek, PeriodMonth:
+		return true
+	}
+	return false
+}
+
+// periodStart returns the first day of the bucket containing d.
+func periodStart(d unit.Date, p LoadPeriod) unit.Date {
+	d = d.StartOfDay()
+	switch p {
+	case PeriodWeek:
+		return d.AddDays(-((int(d.Time.Weekday()) + 6) % 7))
+	case PeriodMonth:
+		return unit.NewDate(d.Time.Year(), d.Time.Month(), 1)
+	}
+	return d
+}
+
+// nextPeriod returns the first day of the bucket after the one starting at d.
+func nextPeriod(d unit.Date, p LoadPeriod) unit.Date {
+	switch p {
+	case PeriodWeek:
+		return d.AddDays(7)
+	case PeriodMonth:
+		return unit.Date{Time: d.Time.AddDate(0, 1, 0)}
+	}
+	return d.AddDays(1)
+}
+
+// ResourceUsage is the work and cost of a resource in each period of a workload report.
+type ResourceUsage struct {
+	Name       string
+	Resource   *resource.Resource // Pool resource, nil for per-activity human resources and assets
+	Hours      []float64          // Work hours per period, aligned with Workload.Periods
+	Costs      []float64          // Cost per period
+	TotalHours float64
+	TotalCost  float64
+}
+
+// Workload is the time-phased work and cost of every resource.
+type Workload struct {
+	Period    LoadPeriod
+	Periods   []unit.Date // First day of each period, consecutive
+	Currency  string
+	Resources []*ResourceUsage // Sorted by name
+}
+
+// Workload returns the work and cost of every resource per period, from the early schedule starting at
+// projectStart with continuous time and costs added without currency conversion; see Project.Workload for the
+// project calendar and currency.
+func (a *Activity) Workload(projectStart unit.Date, period LoadPeriod) *Workload {
+	w, _ := a.workload(projectStart, period, defaultScheduleOptions(), nil)
+	return w
+}
+
+// workload spreads the work and cost of each resource of an activity over the days of the activity's own work
+// window, in proportion to the working hours of each day (continuous time: the hours of the window in each day).
+// Per-activity human resources and assets are matched by name, pool resources by identity, as in ResourceLoads.
+// Work hours are the resource duration for human resources and assets (the whole window if not set),
+// the work hours for assignments. A pool resource with its own calendar works only on its working days within
+// the days of the window, in proportion to its working hours. Costs are converted with c, or added as they are
+// (in the first currency seen) if c is nil.
+func (a *Activity) workload(projectStart unit.Date, period LoadPeriod, opts scheduleOptions, c *CurrencyConversion) (*Workload, error) {
+	opts.start = &projectStart
+	m, _ := a.calculateSlack(opts)
+
+	type entry struct {
+		usage *ResourceUsage
+		days  map[string]float64 // hours per day
+		costs map[string]float64
+	}
+	entries := make(map[string]*entry)
+	w := &Workload{Period: period, Currency: c.reportCurrency("")}
+	first, last := projectStart.StartOfDay(), projectStart.StartOfDay()
+
+	book := func(key, name string, r *resource.Resource, hours, cost float64, currency string, shares map[string]float64) error {
+		cost, err := c.convertFloat(cost, currency)
+		if err != nil {
+			return err
+		}
+		e, ok := entries[key]
+		if !ok {
+			e = &entry{usage: &ResourceUsage{Name: name, Resource: r}, days: make(map[string]float64), costs: make(map[string]float64)}
+			entries[key] = e
+		}
+		for day, s := range shares {
+			e.days[day] += hours * s
+			e.costs[day] += cost * s
+		}
+		if w.Currency == "" {
+			w.Currency = currency
+		}
+		return nil
+	}
+
+	for _, act := range a.GetActivities() {
+		info, ok := m[act]
+		if !ok || len(act.HumanResources)+len(act.Assets)+len(act.Assignments) == 0 {
+			continue
+		}
+		window := opts.activityHours(act)
+		shares, from, to := dayShares(projectStart, opts, info.ES, info.ES+window)
+		if from.Time.Before(first.Time) {
+			first = from
+		}
+		if to.Time.After(last.Time) {
+			last = to
+		}
+		for _, h := range act.HumanResources {
+			if err := book("human:"+resourceKey(h.Name), h.Name, nil, usedHours(h.Duration, window, opts.conv), h.CalculatePrice(), h.Price.Currency, shares); err != nil {
+				return nil, fmt.Errorf("%s: %w", act.Name, err)
+			}
+		}
+		for _, as := range act.Assets {
+			if err := book("asset:"+resourceKey(as.Name), as.Name, nil, usedHours(as.Duration, window, opts.conv), as.CalculatePrice(), as.Price.Currency, shares); err != nil {
+				return nil, fmt.Errorf("%s: %w", act.Name, err)
+			}
+		}
+		for _, as := range act.Assignments {
+			if as.Resource == nil {
+				continue
+			}
+			resShares := shares
+			if cal := as.Resource.Calendar; cal != nil {
+				if s, ok := calendarShares(cal, from, to); ok {
+					resShares = s
+				}
+			}
+			if err := book(fmt.Sprintf("pool:%p", as.Resource), as.Resource.Name, as.Resource, as.WorkHours, as.CalculatePrice(), as.Resource.Rate.Currency, resShares); err != nil {
+				return nil, fmt.Errorf("%s: %w", act.Name, err)
+			}
+		}
+	}
+
+	index := make(map[string]int)
+	for d := periodStart(first, period); !d.Time.After(last.Time); d = nextPeriod(d, period) {
+		index[d.String()] = len(w.Periods)
+		w.Periods = append(w.Periods, d)
+	}
+	for _, e := range entries {
+		u := e.usage
+		u.Hours = make([]float64, len(w.Periods))
+		u.Costs = make([]float64, len(w.Periods))
+		for day, h := range e.days {
+			d, _ := unit.ParseDate(day)
+			i := index[periodStart(d, period).String()]
+			u.Hours[i] += h
+			u.Costs[i] += e.costs[day]
+			u.TotalHours += h
+			u.TotalCost += e.costs[day]
+		}
+		w.Resources = append(w.Resources, u)
+	}
+	sort.SliceStable(w.Resources, func(i, j int) bool { return w.Resources[i].Name < w.Resources[j].Name })
+	return w, nil
+}
+
+// usedHours returns the hours of a per-activity resource: its duration, or the activity window if the duration is not set.
+func usedHours(d unit.Duration, window float64, conv unit.DurationConversion) float64 {
+	if h := d.ToHoursWith(conv); h > 0 {
+		return h
+	}
+	return window
+}
+
+// dayShares returns the share of the window [es, ef) (hours) falling on each day, keyed by YYYY-MM-DD,
+// and the first and last day of the window. A zero-length window falls entirely on its start day.
+func dayShares(projectStart unit.Date, opts scheduleOptions, es, ef float64) (map[string]float64, unit.Date, unit.Date) {
+	start, end := scheduleDates(projectStart, opts.cal, opts.conv, es, ef)
+	shares := make(map[string]float64)
+	if ef <= es {
+		day := start.StartOfDay()
+		shares[day.String()] = 1
+		return shares, day, day
+	}
+	if opts.cal != nil && opts.conv.HoursPerDay > 0 {
+		// Working days from the start to the finish date, both included.
+		from, to := start.StartOfDay(), end.StartOfDay()
+		if s, ok := calendarShares(opts.cal, from, to); ok {
+			return s, from, to
+		}
+		shares[from.String()] = 1
+		return shares, from, to
+	}
+	last := end
+	if last.Time.Equal(last.StartOfDay().Time) {
+		last = last.AddDays(-1) // the window ends at midnight: nothing falls on that day
+	}
+	for d := start.StartOfDay(); !d.Time.After(last.Time); d = d.AddDays(1) {
+		from, to := d.Time, d.AddDays(1).Time
+		if start.Time.After(from) {
+			from = start.Time
+		}
+		if end.Time.Before(to) {
+			to = end.Time
+		}
+		if overlap := to.Sub(from); overlap > 0 {
+			shares[d.String()] = overlap.Hours() / (ef - es)
+		}
+	}
+	return shares, start.StartOfDay(), last.StartOfDay()
+}
+
+// calendarShares returns the share of the working hours of cal falling on each day from from to to (both included),
+// keyed by YYYY-MM-DD. It returns false if the calendar has no working hours in those days.
+func calendarShares(cal *unit.Calendar, from, to unit.Date) (map[string]float64, bool) {
+	total := cal.WorkingHoursBetween(from, to.AddDays(1))
+	if total <= 0 {
+		return nil, false
+	}
+	shares := make(map[string]float64)
+	for d := from; !d.Time.After(to.Time); d = d.AddDays(1) {
+		if h := cal.WorkingHoursOn(d); h > 0 {
+			shares[d.String()] = h / total
+		}
+	}
+	return shares, true
+}
+
+// Workload returns the work and cost of every resource per period using the project settings. Costs are in the
+// reporting currency, converted with the exchange rates effective on the conversion date; it fails if a currency
+// has no exchange rate on that date.
+func (p *Project) Workload(projectStart unit.Date, period LoadPeriod) (*Workload, error) {
+	c := p.CurrencyConversion("", p.ConversionDate())
+	return p.Root.workload(projectStart, period, p.scheduleOptions(), &c)
+}
+
+// PeakHours returns the highest work hours of a resource in a single period.
+func (u *ResourceUsage) PeakHours() float64 {
+	peak := 0.0
+	for _, h := range u.Hours {
+		if h > peak {
+			peak = h
+		}
+	}
+	return peak
+}
+
+// PrintWorkload prints an ASCII histogram of the work hours of each resource per period, with the cost
+// of each period. Bars are scaled to the busiest period of all resources; width is the bar width (default 40).
+func PrintWorkload(w *Workload, width int) {
+	if width <= 0 {
+		width = 40
+	}
+	peak := 0.0
+	for _, u := range w.Resources {
+		if h := u.PeakHours(); h > peak {
+			peak = h
+		}
+	}
+	if peak <= 0 {
+		peak = 1
+	}
+
+	fmt.Println("--------------------------------")
+	fmt.Println("   Resource Workload")
+	fmt.Println("--------------------------------")
+	if len(w.Resources) == 0 {
+		fmt.Println("No resources.")
+		return
+	}
+	fmt.Printf("Period: %s | Full bar: %.1f hours\n", w.Period, peak)
+	for _, u := range w.Resources {
+		fmt.Println("--------------------------------")
+		fmt.Printf("%s (%.1f hours, %.2f %s)\n", u.Name, u.TotalHours, u.TotalCost, w.Currency)
+		for i, d := range w.Periods {
+			n := int(u.Hours[i] / peak * float64(width))
+			if n == 0 && u.Hours[i] > slackEpsilon {
+				n = 1
+			}
+			bar := strings.Repeat("█", n) + strings.Repeat("░", width-n)
+			fmt.Printf("  %s |%s| %7.1fh %10.2f\n", d.String(), bar, u.Hours[i], u.Costs[i])
+		}
+	}
+}
+
+// WriteWorkloadCSV writes the workload as CSV, one row per resource and period:
+// resource, period_start, hours, cost, currency.
+func WriteWorkloadCSV(out io.Writer, w *Workload) error {
+	cw := csv.NewWriter(out)
+	if err := cw.Write([]string{"resource", "period_start", "hours", "cost", "currency"}); err != nil {
+		return err
+	}
+	for _, u := range w.Resources {
+		for i, d := range w.Periods {
+			row := []string{
+				u.Name,
+				d.String(),
+				strconv.FormatFloat(u.Hours[i], 'f', 2, 64),
+				strconv.FormatFloat(u.Costs[i], 'f', 2, 64),
+				w.Currency,
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"explosio/core/resource"
+	"explosio/core/resource/human"
+	"explosio/core/unit"
+)
+
+func TestWorkload_DailyAndWeekly(t *testing.T) {
+	// Plaster (4d) and Tiling (3d) both use the electrician for 1 day (24h at calendar time, 200 EUR).
+	root, plaster, tiling := buildLinkTestTree()
+	plaster.AddHumanResource(newElectrician())
+	tiling.AddHumanResource(newElectrician())
+	start := unit.NewDate(2026, time.March, 2) // Monday
+
+	daily := root.Workload(start, PeriodDay)
+	if len(daily.Resources) != 1 || len(daily.Periods) != 4 {
+		t.Fatalf("daily: %d resources, %d periods, want 1 and 4", len(daily.Resources), len(daily.Periods))
+	}
+	u := daily.Resources[0]
+	// 6h/day from Plaster over 4 days, 8h/day from Tiling over 3 days.
+	wantHours := []float64{14, 14, 14, 6}
+	for i, want := range wantHours {
+		if diff := u.Hours[i] - want; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("day %d hours = %v, want %v", i, u.Hours[i], want)
+		}
+	}
+	if u.TotalHours != 48 || u.TotalCost != 400 || daily.Currency != "EUR" {
+		t.Errorf("totals = %vh %v %s, want 48h 400 EUR", u.TotalHours, u.TotalCost, daily.Currency)
+	}
+
+	weekly := root.Workload(start, PeriodWeek)
+	if len(weekly.Periods) != 1 || weekly.Resources[0].Hours[0] != 48 {
+		t.Errorf("weekly = %v %v, want one week of 48h", weekly.Periods, weekly.Resources[0].Hours)
+	}
+}
+
+func TestWorkload_WeeksStartOnMonday(t *testing.T) {
+	root, plaster, _ := buildLinkTestTree()
+	plaster.AddHumanResource(newElectrician())
+	w := root.Workload(unit.NewDate(2026, time.March, 6), PeriodWeek) // Friday: Plaster runs Fri-Mon
+
+	if len(w.Periods) != 2 || w.Periods[0].String() != "2026-03-02" || w.Periods[1].String() != "2026-03-09" {
+		t.Fatalf("periods = %v, want weeks of 2026-03-02 and 2026-03-09", w.Periods)
+	}
+	if h := w.Resources[0].Hours; h[0] != 18 || h[1] != 6 {
+		t.Errorf("hours = %v, want [18 6]", h)
+	}
+}
+
+func TestWorkload_ResourceCalendar(t *testing.T) {
+	// Plaster runs Monday to Thursday; the crane only works on Mondays and Wednesdays.
+	root, plaster, _ := buildLinkTestTree()
+	crane := resource.NewResource("Crane", "", *unit.NewPrice(50, "EUR"))
+	crane.Calendar = &unit.Calendar{WorkingDays: []time.Weekday{time.Monday, time.Wednesday}, HoursPerDay: 8}
+	plaster.Assign(crane, 1, 16)
+
+	w := root.Workload(unit.NewDate(2026, time.March, 2), PeriodDay)
+	if h := w.Resources[0].Hours; len(h) != 4 || h[0] != 8 || h[1] != 0 || h[2] != 8 || h[3] != 0 {
+		t.Errorf("hours = %v, want [8 0 8 0]", h)
+	}
+
+	crane.Calendar = nil
+	w = root.Workload(unit.NewDate(2026, time.March, 2), PeriodDay)
+	if h := w.Resources[0].Hours; len(h) != 4 || h[0] != 4 || h[3] != 4 {
+		t.Errorf("without calendar hours = %v, want 4 per day", h)
+	}
+}
+
+func TestWriteWorkloadCSV(t *testing.T) {
+	root, plaster, _ := buildLinkTestTree()
+	plaster.AddHumanResource(newElectrician())
+	var buf bytes.Buffer
+	if err := WriteWorkloadCSV(&buf, root.Workload(unit.NewDate(2026, time.March, 2), PeriodWeek)); err != nil {
+		t.Fatal(err)
+	}
+	want := "resource,period_start,hours,cost,currency\nElectrician,2026-03-02,24.00,200.00,EUR\n"
+	if got := buf.String(); got != want {
+		t.Errorf("CSV = %q, want %q", got, want)
+	}
+}
+
+func TestProject_WorkloadConvertsCosts(t *testing.T) {
+	proj, tiling := buildCurrencyProject()
+	tiling.AddHumanResource(human.NewHumanResource("Tiler", "", *unit.NewDuration(1, unit.DurationUnitDay), *unit.NewPrice(1000, "CHF")))
+
+	w, err := proj.Workload(unit.NewDate(2026, time.March, 2), PeriodWeek)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// June rate 1.10: the tiler costs 1100 EUR, the electrician 200 EUR.
+	if len(w.Resources) != 2 || w.Currency != "EUR" {
+		t.Fatalf("workload = %d resources in %s, want 2 in EUR", len(w.Resources), w.Currency)
+	}
+	if u := w.Resources[1]; u.Name != "Tiler" || math.Abs(u.TotalCost-1100) > 1e-9 {
+		t.Errorf("%s = %v, want Tiler 1100 EUR", u.Name, u.TotalCost)
+	}
+}
@@ -31,6 +31,8 @@ func BuildDemoTree() *core.Activity {
 		WithName("Kitchen Renovation").
 		WithDescription("Kitchen remodeling").
 		WithDuration(*unit.NewDuration(15, unit.DurationUnitDay)).
+		WithEstimate(core.NewThreePointEstimate(12, 15, 24, unit.DurationUnitDay)).
+		WithCostEstimate(&core.CostEstimate{Optimistic: 18000, MostLikely: 20000, Pessimistic: 26000}).
 		WithPrice(*unit.NewPrice(20000, "EUR")).
 		Build())
 
@@ -38,6 +40,12 @@ func BuildDemoTree() *core.Activity {
 		WithName("Bathroom Renovation").
 		WithDescription("Bathroom remodeling").
 		WithDuration(*unit.NewDuration(10, unit.DurationUnitDay)).
+		WithEstimate(&core.ThreePointEstimate{
+			Optimistic:   *unit.NewDuration(8, unit.DurationUnitDay),
+			MostLikely:   *unit.NewDuration(10, unit.DurationUnitDay),
+			Pessimistic:  *unit.NewDuration(20, unit.DurationUnitDay),
+			Distribution: core.DistributionTriangular,
+		}).
 		WithPrice(*unit.NewPrice(12000, "EUR")).
 		Build())
 
@@ -45,6 +53,7 @@ func BuildDemoTree() *core.Activity {
 		WithName("Install pipes").
 		WithDescription("Plumbing installation in kitchen").
 		WithDuration(*unit.NewDuration(3, unit.DurationUnitDay)).
+		WithEstimate(core.NewThreePointEstimate(2, 3, 6, unit.DurationUnitDay)).
 		WithPrice(*unit.NewPrice(2500, "EUR")).
 		Build())
 
@@ -2,6 +2,7 @@ package gui
 
 import (
 	"explosio/core"
+	"explosio/core/catalog"
 	"explosio/core/unit"
 	"strconv"
 
@@ -26,11 +27,20 @@ type ActivityForm struct {
 	descEntry       *widget.Entry
 	durationEntry   *widget.Entry
 	durationSelect  *widget.Select
+	optimisticEntry  *widget.Entry // Stima a tre punti (PERT), nell'unità della durata
+	mostLikelyEntry  *widget.Entry
+	pessimisticEntry *widget.Entry
+	percentEntry     *widget.Entry // Avanzamento: percentuale, date effettive (YYYY-MM-DD), durata residua
+	actualStartEntry *widget.Entry
+	actualFinishEntry *widget.Entry
+	remainingEntry   *widget.Entry
+	actualCostEntry  *widget.Entry
 	priceEntry      *widget.Entry
 	currencyEntry   *widget.Entry
 
 	// Materials/resources accordion (from form_materials.go)
 	materialsAccordion *materialsAccordion
+	catalog            *catalog.Catalog // Catalogo prezzi per l'autocompletamento dei materiali (nil = nessuno)
 
 	content *fyne.Container
 }
@@ -59,6 +69,32 @@ func NewActivityForm(root *core.Activity, onRefresh func(), win fyne.Window) *Ac
 	f.durationSelect = widget.NewSelect(durationUnits, func(string) { f.onFieldChanged("") })
 	f.durationSelect.SetSelected("day")
 
+	f.optimisticEntry = widget.NewEntry()
+	f.optimisticEntry.SetPlaceHolder("Ottimistica")
+	f.optimisticEntry.OnChanged = f.onFieldChanged
+	f.mostLikelyEntry = widget.NewEntry()
+	f.mostLikelyEntry.SetPlaceHolder("Più probabile")
+	f.mostLikelyEntry.OnChanged = f.onFieldChanged
+	f.pessimisticEntry = widget.NewEntry()
+	f.pessimisticEntry.SetPlaceHolder("Pessimistica")
+	f.pessimisticEntry.OnChanged = f.onFieldChanged
+
+	f.percentEntry = widget.NewEntry()
+	f.percentEntry.SetPlaceHolder("0")
+	f.percentEntry.OnChanged = f.onFieldChanged
+	f.actualStartEntry = widget.NewEntry()
+	f.actualStartEntry.SetPlaceHolder("YYYY-MM-DD")
+	f.actualStartEntry.OnChanged = f.onFieldChanged
+	f.actualFinishEntry = widget.NewEntry()
+	f.actualFinishEntry.SetPlaceHolder("YYYY-MM-DD")
+	f.actualFinishEntry.OnChanged = f.onFieldChanged
+	f.remainingEntry = widget.NewEntry()
+	f.remainingEntry.SetPlaceHolder("Calcolata")
+	f.remainingEntry.OnChanged = f.onFieldChanged
+	f.actualCostEntry = widget.NewEntry()
+	f.actualCostEntry.SetPlaceHolder("0")
+	f.actualCostEntry.OnChanged = f.onFieldChanged
+
 	f.priceEntry = widget.NewEntry()
 	f.priceEntry.SetPlaceHolder("0")
 	f.priceEntry.OnChanged = f.onFieldChanged
@@ -71,6 +107,11 @@ func NewActivityForm(root *core.Activity, onRefresh func(), win fyne.Window) *Ac
 		widget.NewFormItem("Nome", f.nameEntry),
 		widget.NewFormItem("Descrizione", f.descEntry),
 		widget.NewFormItem("Durata", container.NewHBox(f.durationEntry, f.durationSelect)),
+		widget.NewFormItem("Stima (O/M/P)", container.NewGridWithColumns(3, f.optimisticEntry, f.mostLikelyEntry, f.pessimisticEntry)),
+		widget.NewFormItem("Avanzamento %", f.percentEntry),
+		widget.NewFormItem("Inizio/fine effettivi", container.NewGridWithColumns(2, f.actualStartEntry, f.actualFinishEntry)),
+		widget.NewFormItem("Durata residua", f.remainingEntry),
+		widget.NewFormItem("Costo effettivo", f.actualCostEntry),
 		widget.NewFormItem("Prezzo", f.priceEntry),
 		widget.NewFormItem("Valuta", f.currencyEntry),
 	)
@@ -114,6 +155,8 @@ func (f *ActivityForm) saveToActivity() {
 		f.current.Duration.Value = v
 	}
 	f.current.Duration.Unit = unit.DurationUnit(f.durationSelect.Selected)
+	f.saveEstimate()
+	f.saveProgress()
 
 	if v, err := strconv.ParseFloat(f.priceEntry.Text, 64); err == nil {
 		f.current.Price.Value = v
@@ -133,6 +176,14 @@ func (f *ActivityForm) loadFromActivity(a *core.Activity) {
 		f.descEntry.SetText("")
 		f.durationEntry.SetText("0")
 		f.durationSelect.SetSelected("day")
+		f.optimisticEntry.SetText("")
+		f.mostLikelyEntry.SetText("")
+		f.pessimisticEntry.SetText("")
+		f.percentEntry.SetText("")
+		f.actualStartEntry.SetText("")
+		f.actualFinishEntry.SetText("")
+		f.remainingEntry.SetText("")
+		f.actualCostEntry.SetText("")
 		f.priceEntry.SetText("0")
 		f.currencyEntry.SetText("EUR")
 		f.materialsAccordion.setActivity(nil)
@@ -147,6 +198,8 @@ func (f *ActivityForm) loadFromActivity(a *core.Activity) {
 		unitStr = "day"
 	}
 	f.durationSelect.SetSelected(unitStr)
+	f.loadEstimate(a)
+	f.loadProgress(a)
 	f.priceEntry.SetText(strconv.FormatFloat(a.Price.Value, 'f', -1, 64))
 	f.currencyEntry.SetText(a.Price.Currency)
 	if f.currencyEntry.Text == "" {
@@ -156,6 +209,113 @@ func (f *ActivityForm) loadFromActivity(a *core.Activity) {
 	f.materialsAccordion.setActivity(a)
 }
 
+// saveEstimate aggiorna la stima a tre punti: tre valori validi nell'unità della durata, campi vuoti la rimuovono.
+// La distribuzione della stima esistente viene mantenuta.
+func (f *ActivityForm) saveEstimate() {
+	if f.optimisticEntry.Text == "" && f.mostLikelyEntry.Text == "" && f.pessimisticEntry.Text == "" {
+		f.current.Estimate = nil
+		return
+	}
+	o, errO := strconv.ParseFloat(f.optimisticEntry.Text, 64)
+	m, errM := strconv.ParseFloat(f.mostLikelyEntry.Text, 64)
+	p, errP := strconv.ParseFloat(f.pessimisticEntry.Text, 64)
+	if errO != nil || errM != nil || errP != nil {
+		return
+	}
+	e := core.NewThreePointEstimate(o, m, p, f.current.Duration.Unit)
+	if f.current.Estimate != nil {
+		e.Distribution = f.current.Estimate.Distribution
+	}
+	f.current.Estimate = e
+}
+
+// loadEstimate mostra la stima a tre punti convertita nell'unità della durata.
+func (f *ActivityForm) loadEstimate(a *core.Activity) {
+	if a.Estimate == nil {
+		f.optimisticEntry.SetText("")
+		f.mostLikelyEntry.SetText("")
+		f.pessimisticEntry.SetText("")
+		return
+	}
+	u := a.Duration.Unit
+	if u == "" {
+		u = unit.DurationUnitDay
+	}
+	f.optimisticEntry.SetText(strconv.FormatFloat(durationIn(a.Estimate.Optimistic, u), 'f', -1, 64))
+	f.mostLikelyEntry.SetText(strconv.FormatFloat(durationIn(a.Estimate.MostLikely, u), 'f', -1, 64))
+	f.pessimisticEntry.SetText(strconv.FormatFloat(durationIn(a.Estimate.Pessimistic, u), 'f', -1, 64))
+}
+
+// saveProgress aggiorna l'avanzamento: percentuale, date effettive, durata residua (nell'unità della durata) e costo effettivo.
+// Campi vuoti rimuovono il valore; valori non validi vengono ignorati finché la digitazione non è completa.
+func (f *ActivityForm) saveProgress() {
+	if f.percentEntry.Text == "" {
+		f.current.PercentComplete = 0
+	} else if v, err := strconv.ParseFloat(f.percentEntry.Text, 64); err == nil {
+		f.current.PercentComplete = v
+	}
+	f.current.ActualStart = parseDateEntry(f.actualStartEntry.Text, f.current.ActualStart)
+	f.current.ActualFinish = parseDateEntry(f.actualFinishEntry.Text, f.current.ActualFinish)
+	if f.remainingEntry.Text == "" {
+		f.current.RemainingDuration = nil
+	} else if v, err := strconv.ParseFloat(f.remainingEntry.Text, 64); err == nil {
+		f.current.RemainingDuration = unit.NewDuration(v, f.current.Duration.Unit)
+	}
+	if f.actualCostEntry.Text == "" {
+		f.current.ActualCost = 0
+	} else if v, err := strconv.ParseFloat(f.actualCostEntry.Text, 64); err == nil {
+		f.current.ActualCost = v
+	}
+}
+
+// loadProgress mostra l'avanzamento dell'attività; la durata residua è convertita nell'unità della durata.
+func (f *ActivityForm) loadProgress(a *core.Activity) {
+	f.percentEntry.SetText("")
+	if a.PercentComplete != 0 {
+		f.percentEntry.SetText(strconv.FormatFloat(a.PercentComplete, 'f', -1, 64))
+	}
+	f.actualStartEntry.SetText("")
+	if a.ActualStart != nil {
+		f.actualStartEntry.SetText(a.ActualStart.String())
+	}
+	f.actualFinishEntry.SetText("")
+	if a.ActualFinish != nil {
+		f.actualFinishEntry.SetText(a.ActualFinish.String())
+	}
+	f.remainingEntry.SetText("")
+	if a.RemainingDuration != nil {
+		u := a.Duration.Unit
+		if u == "" {
+			u = unit.DurationUnitDay
+		}
+		f.remainingEntry.SetText(strconv.FormatFloat(durationIn(*a.RemainingDuration, u), 'f', -1, 64))
+	}
+	f.actualCostEntry.SetText("")
+	if a.ActualCost != 0 {
+		f.actualCostEntry.SetText(strconv.FormatFloat(a.ActualCost, 'f', -1, 64))
+	}
+}
+
+// parseDateEntry restituisce la data inserita, nil se il campo è vuoto, il valore precedente se la data non è valida.
+func parseDateEntry(text string, prev *unit.Date) *unit.Date {
+	if text == "" {
+		return nil
+	}
+	d, err := unit.ParseDate(text)
+	if err != nil {
+		return prev
+	}
+	return &d
+}
+
+// durationIn restituisce il valore della durata espresso nell'unità u.
+func durationIn(d unit.Duration, u unit.DurationUnit) float64 {
+	if d.Unit == u {
+		return d.Value
+	}
+	return d.ToHours() / unit.NewDuration(1, u).ToHours()
+}
+
 // SelectActivity loads the given activity into the form. Call when tree selection changes.
 func (f *ActivityForm) SelectActivity(a *core.Activity) {
 	f.loadFromActivity(a)
@@ -171,6 +331,11 @@ func (f *ActivityForm) Current() *core.Activity {
 	return f.current
 }
 
+// SetCatalog sets the price catalog offered by the material dialogs (nil = no autocomplete).
+func (f *ActivityForm) SetCatalog(c *catalog.Catalog) {
+	f.catalog = c
+}
+
 // SetWindow sets the window for dialogs (e.g. when adding materials).
 func (f *ActivityForm) SetWindow(win fyne.Window) {
 	f.win = win
@@ -2,11 +2,13 @@ package gui
 
 import (
 	"explosio/core"
+	"explosio/core/catalog"
 	"explosio/core/material"
 	"explosio/core/resource/asset"
 	"explosio/core/resource/human"
 	"explosio/core/unit"
 	"fmt"
+	"math"
 	"strconv"
 
 	"fyne.io/fyne/v2"
@@ -257,7 +259,7 @@ func (m *materialsAccordion) showComplexMaterialDialog(existing *material.Comple
 	if m.activity == nil {
 		return
 	}
-	nameE := widget.NewEntry()
+	picker := newCatalogPicker(m.form.catalog, catalog.KindCountable)
 	descE := widget.NewEntry()
 	priceE := widget.NewEntry()
 	priceE.SetPlaceHolder("0")
@@ -266,18 +268,30 @@ func (m *materialsAccordion) showComplexMaterialDialog(existing *material.Comple
 	qtyE := widget.NewEntry()
 	qtyE.SetPlaceHolder("1")
 	if existing != nil {
-		nameE.SetText(existing.Name)
+		picker.entry.SetText(existing.Name)
+		picker.code, picker.supplier = existing.Code, existing.Supplier
 		descE.SetText(existing.Description)
 		priceE.SetText(strconv.FormatFloat(existing.Price.Value, 'f', -1, 64))
 		currE.SetText(existing.Price.Currency)
 		qtyE.SetText(strconv.Itoa(existing.UnitQuantity))
 	}
+	purchase := newPurchaseFields()
+	if existing != nil {
+		purchase.set(existing.WastePercent, float64(existing.PackSize))
+	}
+	picker.onPick = func(e *catalog.Entry) {
+		descE.SetText(e.Description)
+		priceE.SetText(strconv.FormatFloat(e.Price.Value, 'f', -1, 64))
+		currE.SetText(e.Price.Currency)
+		purchase.set(e.WastePercent, e.PackSize)
+	}
 	items := []*widget.FormItem{
-		widget.NewFormItem("Nome", nameE),
+		widget.NewFormItem("Nome", picker.entry),
 		widget.NewFormItem("Descrizione", descE),
 		widget.NewFormItem("Prezzo", container.NewHBox(priceE, currE)),
 		widget.NewFormItem("Quantità unità", qtyE),
 	}
+	items = append(items, purchase.items()...)
 	callback := func(ok bool) {
 		if !ok {
 			return
@@ -293,7 +307,10 @@ func (m *materialsAccordion) showComplexMaterialDialog(existing *material.Comple
 		}
 		p := unit.Price{Value: priceVal, Currency: curr}
 		meas := material.NewMeasurableMaterial("", "", unit.Price{Value: 0, Currency: curr}, unit.MeasurableQuantity{Value: 1, Unit: unit.UnitMeter})
-		cm := material.NewComplexMaterial(nameE.Text, descE.Text, p, qty, meas)
+		cm := material.NewComplexMaterial(picker.entry.Text, descE.Text, p, qty, meas)
+		waste, pack := purchase.values()
+		cm.WastePercent, cm.PackSize = waste, int(pack)
+		cm.Code, cm.Supplier = picker.code, picker.supplier
 		if existing != nil {
 			for i, c := range m.activity.ComplexMaterials {
 				if c == existing {
@@ -316,7 +333,7 @@ func (m *materialsAccordion) showCountableMaterialDialog(existing *material.Coun
 	if m.activity == nil {
 		return
 	}
-	nameE := widget.NewEntry()
+	picker := newCatalogPicker(m.form.catalog, catalog.KindCountable)
 	descE := widget.NewEntry()
 	priceE := widget.NewEntry()
 	priceE.SetPlaceHolder("0")
@@ -325,18 +342,30 @@ func (m *materialsAccordion) showCountableMaterialDialog(existing *material.Coun
 	qtyE := widget.NewEntry()
 	qtyE.SetPlaceHolder("1")
 	if existing != nil {
-		nameE.SetText(existing.Name)
+		picker.entry.SetText(existing.Name)
+		picker.code, picker.supplier = existing.Code, existing.Supplier
 		descE.SetText(existing.Description)
 		priceE.SetText(strconv.FormatFloat(existing.Price.Value, 'f', -1, 64))
 		currE.SetText(existing.Price.Currency)
 		qtyE.SetText(strconv.Itoa(existing.Quantity))
 	}
+	purchase := newPurchaseFields()
+	if existing != nil {
+		purchase.set(existing.WastePercent, float64(existing.PackSize))
+	}
+	picker.onPick = func(e *catalog.Entry) {
+		descE.SetText(e.Description)
+		priceE.SetText(strconv.FormatFloat(e.Price.Value, 'f', -1, 64))
+		currE.SetText(e.Price.Currency)
+		purchase.set(e.WastePercent, e.PackSize)
+	}
 	items := []*widget.FormItem{
-		widget.NewFormItem("Nome", nameE),
+		widget.NewFormItem("Nome", picker.entry),
 		widget.NewFormItem("Descrizione", descE),
 		widget.NewFormItem("Prezzo", container.NewHBox(priceE, currE)),
 		widget.NewFormItem("Quantità", qtyE),
 	}
+	items = append(items, purchase.items()...)
 	callback := func(ok bool) {
 		if !ok {
 			return
@@ -351,7 +380,10 @@ func (m *materialsAccordion) showCountableMaterialDialog(existing *material.Coun
 			curr = "EUR"
 		}
 		p := unit.Price{Value: priceVal, Currency: curr}
-		cm := material.NewCountableMaterial(nameE.Text, descE.Text, p, qty)
+		cm := material.NewCountableMaterial(picker.entry.Text, descE.Text, p, qty)
+		waste, pack := purchase.values()
+		cm.WastePercent, cm.PackSize = waste, int(pack)
+		cm.Code, cm.Supplier = picker.code, picker.supplier
 		if existing != nil {
 			for i, c := range m.activity.CountableMaterials {
 				if c == existing {
@@ -374,7 +406,7 @@ func (m *materialsAccordion) showMeasurableMaterialDialog(existing *material.Mea
 	if m.activity == nil {
 		return
 	}
-	nameE := widget.NewEntry()
+	picker := newCatalogPicker(m.form.catalog, catalog.KindMeasurable)
 	descE := widget.NewEntry()
 	priceE := widget.NewEntry()
 	priceE.SetPlaceHolder("0")
@@ -382,22 +414,53 @@ func (m *materialsAccordion) showMeasurableMaterialDialog(existing *material.Mea
 	currE.SetText("EUR")
 	valE := widget.NewEntry()
 	valE.SetPlaceHolder("0")
-	unitSelect := widget.NewSelect([]string{"m", "m²", "kg", "g", "day"}, nil)
-	unitSelect.SetSelected("m")
+	// Unità a cui si riferisce il prezzo (es. prezzo al kg con quantità in g): solo unità della stessa dimensione
+	priceUnitSelect := widget.NewSelect(nil, nil)
+	priceUnitSelect.PlaceHolder = "come quantità"
+	qtyUnit := newUnitPicker(unit.UnitMeter)
+	qtyUnit.onChanged = func(d unit.Dimension) {
+		priceUnitSelect.Options = unitOptions(d)
+		if !qtyUnit.selected().Compatible(unit.MeasurableUnit(priceUnitSelect.Selected)) {
+			priceUnitSelect.ClearSelected()
+		}
+		priceUnitSelect.Refresh()
+	}
+	priceUnitSelect.Options = unitOptions(unit.DimensionLength)
 	if existing != nil {
-		nameE.SetText(existing.Name)
+		picker.entry.SetText(existing.Name)
+		picker.code, picker.supplier = existing.Code, existing.Supplier
 		descE.SetText(existing.Description)
 		priceE.SetText(strconv.FormatFloat(existing.Price.Value, 'f', -1, 64))
 		currE.SetText(existing.Price.Currency)
 		valE.SetText(strconv.FormatFloat(existing.Quantity.Value, 'f', -1, 64))
-		unitSelect.SetSelected(string(existing.Quantity.Unit))
+		qtyUnit.setUnit(existing.Quantity.Unit)
+		if existing.PriceUnit != "" {
+			priceUnitSelect.SetSelected(string(existing.PriceUnit))
+		}
+	}
+	purchase := newPurchaseFields()
+	if existing != nil {
+		purchase.set(existing.WastePercent, float64(existing.PackSize))
+	}
+	picker.onPick = func(e *catalog.Entry) {
+		descE.SetText(e.Description)
+		priceE.SetText(strconv.FormatFloat(e.Price.Value, 'f', -1, 64))
+		currE.SetText(e.Price.Currency)
+		// Quantità nella stessa dimensione del prezzo di catalogo; confezione convertita nell'unità della quantità
+		if !qtyUnit.selected().Compatible(e.Unit) {
+			qtyUnit.setUnit(e.Unit)
+		}
+		priceUnitSelect.SetSelected(string(e.Unit))
+		pack, _ := unit.ConvertUnit(e.PackSize, e.Unit, qtyUnit.selected())
+		purchase.set(e.WastePercent, pack)
 	}
 	items := []*widget.FormItem{
-		widget.NewFormItem("Nome", nameE),
+		widget.NewFormItem("Nome", picker.entry),
 		widget.NewFormItem("Descrizione", descE),
-		widget.NewFormItem("Prezzo unitario", container.NewHBox(priceE, currE)),
-		widget.NewFormItem("Quantità", container.NewHBox(valE, unitSelect)),
+		widget.NewFormItem("Prezzo unitario", container.NewHBox(priceE, currE, widget.NewLabel("per"), priceUnitSelect)),
+		widget.NewFormItem("Quantità", container.NewHBox(valE, qtyUnit.container())),
 	}
+	items = append(items, purchase.items()...)
 	callback := func(ok bool) {
 		if !ok {
 			return
@@ -412,8 +475,13 @@ func (m *materialsAccordion) showMeasurableMaterialDialog(existing *material.Mea
 			curr = "EUR"
 		}
 		p := unit.Price{Value: priceVal, Currency: curr}
-		q := unit.MeasurableQuantity{Value: qtyVal, Unit: unit.MeasurableUnit(unitSelect.Selected)}
-		mm := material.NewMeasurableMaterial(nameE.Text, descE.Text, p, q)
+		q := unit.MeasurableQuantity{Value: qtyVal, Unit: qtyUnit.selected()}
+		mm := material.NewMeasurableMaterial(picker.entry.Text, descE.Text, p, q)
+		mm.WastePercent, mm.PackSize = purchase.values()
+		if priceUnitSelect.Selected != string(q.Unit) {
+			mm.PriceUnit = unit.MeasurableUnit(priceUnitSelect.Selected)
+		}
+		mm.Code, mm.Supplier = picker.code, picker.supplier
 		if existing != nil {
 			for i, c := range m.activity.MeasurableMaterials {
 				if c == existing {
@@ -558,3 +626,162 @@ func showFormDialog(title string, items []*widget.FormItem, callback func(bool),
 	d.Resize(fyne.NewSize(400, 300))
 	d.Show()
 }
+
+// dimensionLabels sono i nomi delle dimensioni mostrati nei selettori di unità.
+var dimensionLabels = map[unit.Dimension]string{
+	unit.DimensionLength: "Lunghezza",
+	unit.DimensionArea:   "Superficie",
+	unit.DimensionVolume: "Volume",
+	unit.DimensionMass:   "Massa",
+	unit.DimensionCount:  "Pezzi",
+}
+
+// unitPicker sceglie un'unità di misura in due passi: dimensione, poi unità della dimensione.
+type unitPicker struct {
+	dimension *widget.Select
+	unit      *widget.Select
+	onChanged func(unit.Dimension)
+}
+
+// newUnitPicker crea il selettore con le dimensioni di unit.Dimensions e seleziona l'unità u.
+func newUnitPicker(u unit.MeasurableUnit) *unitPicker {
+	p := &unitPicker{unit: widget.NewSelect(nil, nil)}
+	var labels []string
+	for _, d := range unit.Dimensions {
+		labels = append(labels, dimensionLabels[d])
+	}
+	p.dimension = widget.NewSelect(labels, func(label string) {
+		d := dimensionOf(label)
+		p.unit.Options = unitOptions(d)
+		if !unit.MeasurableUnit(p.unit.Selected).Compatible(unit.UnitsOf(d)[0]) {
+			p.unit.SetSelected(p.unit.Options[0])
+		}
+		p.unit.Refresh()
+		if p.onChanged != nil {
+			p.onChanged(d)
+		}
+	})
+	p.setUnit(u)
+	return p
+}
+
+// setUnit seleziona l'unità; un'unità sconosciuta (file esistenti) resta selezionabile senza dimensione.
+func (p *unitPicker) setUnit(u unit.MeasurableUnit) {
+	if !u.IsValid() {
+		p.unit.Options = []string{string(u)}
+		p.unit.SetSelected(string(u))
+		return
+	}
+	p.dimension.SetSelected(dimensionLabels[u.Dimension()])
+	p.unit.SetSelected(string(u))
+}
+
+// selected restituisce l'unità selezionata.
+func (p *unitPicker) selected() unit.MeasurableUnit {
+	return unit.MeasurableUnit(p.unit.Selected)
+}
+
+// container restituisce i due selettori affiancati.
+func (p *unitPicker) container() fyne.CanvasObject {
+	return container.NewHBox(p.dimension, p.unit)
+}
+
+// dimensionOf restituisce la dimensione con l'etichetta data.
+func dimensionOf(label string) unit.Dimension {
+	for d, l := range dimensionLabels {
+		if l == label {
+			return d
+		}
+	}
+	return ""
+}
+
+// unitOptions restituisce le unità della dimensione come opzioni di un Select.
+func unitOptions(d unit.Dimension) []string {
+	var options []string
+	for _, u := range unit.UnitsOf(d) {
+		options = append(options, string(u))
+	}
+	return options
+}
+
+// purchaseFields sono i campi di acquisto di un materiale: sfrido (%) e dimensione della confezione.
+type purchaseFields struct {
+	waste *widget.Entry
+	pack  *widget.Entry
+}
+
+func newPurchaseFields() purchaseFields {
+	p := purchaseFields{waste: widget.NewEntry(), pack: widget.NewEntry()}
+	p.waste.SetPlaceHolder("0")
+	p.pack.SetPlaceHolder("0 = sfuso")
+	return p
+}
+
+// set mostra sfrido e confezione di un materiale esistente (vuoti se zero).
+func (p purchaseFields) set(waste, pack float64) {
+	if waste != 0 {
+		p.waste.SetText(strconv.FormatFloat(waste, 'f', -1, 64))
+	}
+	if pack != 0 {
+		p.pack.SetText(strconv.FormatFloat(pack, 'f', -1, 64))
+	}
+}
+
+func (p purchaseFields) items() []*widget.FormItem {
+	return []*widget.FormItem{
+		widget.NewFormItem("Sfrido %", p.waste),
+		widget.NewFormItem("Confezione", p.pack),
+	}
+}
+
+// values restituisce sfrido e confezione; valori negativi o non numerici valgono 0.
+func (p purchaseFields) values() (float64, float64) {
+	waste, _ := strconv.ParseFloat(p.waste.Text, 64)
+	pack, _ := strconv.ParseFloat(p.pack.Text, 64)
+	return math.Max(waste, 0), math.Max(pack, 0)
+}
+
+// catalogPicker è il campo Nome con autocompletamento dal catalogo: scrivendo propone le voci del tipo
+// richiesto che contengono il testo; scegliendo una voce imposta nome, codice e fornitore e chiama onPick
+// per compilare prezzo e confezione.
+type catalogPicker struct {
+	entry    *widget.SelectEntry
+	code     string
+	supplier string
+	onPick   func(*catalog.Entry)
+}
+
+// newCatalogPicker crea il campo per le voci di tipo kind; senza catalogo è un campo di testo semplice.
+func newCatalogPicker(c *catalog.Catalog, kind catalog.Kind) *catalogPicker {
+	p := &catalogPicker{entry: widget.NewSelectEntry(nil)}
+	if c == nil {
+		return p
+	}
+	byLabel := make(map[string]*catalog.Entry)
+	options := func(text string) []string {
+		var labels []string
+		for _, e := range c.Search(text) {
+			if e.Kind != kind {
+				continue
+			}
+			label := fmt.Sprintf("%s – %s (%s)", e.Code, e.Name, e.PriceLabel())
+			byLabel[label] = e
+			labels = append(labels, label)
+		}
+		return labels
+	}
+	p.entry.SetOptions(options(""))
+	p.entry.OnChanged = func(text string) {
+		if e, ok := byLabel[text]; ok {
+			p.code, p.supplier = e.Code, e.Supplier
+			p.entry.SetText(e.Name)
+			if p.onPick != nil {
+				p.onPick(e)
+			}
+			return
+		}
+		p.entry.SetOptions(options(text))
+	}
+	return p
+}
@@ -2,8 +2,12 @@ package gui
 
 import (
 	"explosio/core"
+	"explosio/core/catalog"
 	"explosio/core/unit"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"fyne.io/fyne/v2"
@@ -25,6 +29,7 @@ func Run(root *core.Activity) {
 	var tree *widget.Tree
 	var form *ActivityForm
 	var proj *core.Project = core.NewProject(root)
+	var cat *catalog.Catalog
 
 	refreshTree := func() {
 		if tree != nil {
@@ -55,6 +60,33 @@ func Run(root *core.Activity) {
 	)
 	split.SetOffset(0.3)
 
+	// Barra di stato: totale nella valuta scelta, convertito con i cambi del progetto
+	statusLabel := widget.NewLabel("")
+	currencySelect := widget.NewSelect(nil, nil)
+	updateStatus := func() {
+		totalDur := root.CalculateDuration()
+		currency := currencySelect.Selected
+		totalPrice, err := proj.CalculatePriceIn(currency, proj.ConversionDate())
+		if err != nil {
+			// Cambio mancante: somma senza conversione, con l'avviso
+			statusLabel.SetText(fmt.Sprintf("Totale: %.0f %s (%v) | Durata: %.0f %s", root.CalculatePrice(), root.Price.Currency, err, totalDur, root.Duration.Unit))
+			return
+		}
+		statusLabel.SetText(fmt.Sprintf("Totale: %.0f %s | Durata: %.0f %s", totalPrice, currency, totalDur, root.Duration.Unit))
+	}
+	setCurrencies := func() {
+		currencySelect.OnChanged = nil
+		currencySelect.Options = proj.Currencies()
+		currencySelect.SetSelected(proj.ReportingCurrency())
+		currencySelect.OnChanged = func(string) { updateStatus() }
+	}
+	setCurrencies()
+	updateStatus()
+	form.onRefresh = func() {
+		refreshTree()
+		updateStatus()
+	}
+
 	toolbar := widget.NewToolbar(
 		widget.NewToolbarAction(theme.DocumentIcon(), func() {
 			dialog.ShowFileOpen(func(uc fyne.URIReadCloser, err error) {
@@ -73,6 +105,19 @@ func Run(root *core.Activity) {
 					dialog.ShowError(err, w)
 					return
 				}
+				// Catalogo del progetto: percorso relativo al file del progetto
+				var warnings []core.ValidationError
+				if loaded.Catalog != "" {
+					catPath := loaded.Catalog
+					if !filepath.IsAbs(catPath) {
+						catPath = filepath.Join(filepath.Dir(path), catPath)
+					}
+					if cat, err = readCatalogFile(catPath); err != nil {
+						dialog.ShowError(err, w)
+						return
+					}
+					warnings = loaded.ApplyCatalog(cat)
+				}
 				proj = loaded
 				root = loaded.Root
 				tree = NewActivityTree(root, func(selected *core.Activity) {
@@ -83,15 +128,48 @@ func Run(root *core.Activity) {
 				tree.OpenAllBranches()
 				form = NewActivityForm(root, refreshTree, w)
 				form.SetWindow(w)
+				form.SetCatalog(cat)
+				form.onRefresh = func() {
+					refreshTree()
+					updateStatus()
+				}
 				form.SelectActivity(root)
+				setCurrencies()
+				updateStatus()
 				split.Leading = tree
 				split.Trailing = form.Content()
 				split.Refresh()
+				showCatalogWarnings(warnings, w)
+			}, w)
+		}),
+		// Catalogo prezzi: applica i prezzi alle voci con codice e abilita l'autocompletamento dei materiali
+		widget.NewToolbarAction(theme.ListIcon(), func() {
+			dialog.ShowFileOpen(func(uc fyne.URIReadCloser, err error) {
+				if err != nil || uc == nil {
+					return
+				}
+				defer uc.Close()
+				path := uc.URI().Path()
+				loaded, err := readCatalog(path, uc)
+				if err != nil {
+					dialog.ShowError(err, w)
+					return
+				}
+				cat = loaded
+				form.SetCatalog(cat)
+				if proj.Catalog == "" {
+					proj.Catalog = path
+				}
+				warnings := proj.ApplyCatalog(cat)
+				form.SelectActivity(form.Current())
+				updateStatus()
+				showCatalogWarnings(warnings, w)
 			}, w)
 		}),
 		widget.NewToolbarSpacer(),
 		widget.NewToolbarAction(theme.DocumentSaveIcon(), func() {
-			r := root.Validate()
+			proj.Root = root
+			r := proj.Validate()
 			if !r.Valid() {
 				var msg strings.Builder
 				for _, e := range r.Errors {
@@ -107,15 +185,33 @@ func Run(root *core.Activity) {
 				dialog.ShowInformation("Validazione", "Errori prima del salvataggio:\n\n"+msg.String(), w)
 				return
 			}
-			dialog.ShowFileSave(func(uc fyne.URIWriteCloser, err error) {
-				if err != nil || uc == nil {
-					return
-				}
-				defer uc.Close()
-				proj = core.NewProject(root)
-				if err := proj.WriteJSON(uc); err != nil {
-					dialog.ShowError(err, w)
-					return
+			save := func() {
+				dialog.ShowFileSave(func(uc fyne.URIWriteCloser, err error) {
+					if err != nil || uc == nil {
+						return
+					}
+					defer uc.Close()
+					// Mantiene le impostazioni del progetto (calendario, pool di risorse, ...) caricate da file
+					if err := proj.WriteJSON(uc); err != nil {
+						dialog.ShowError(err, w)
+						return
+					}
+				}, w)
+			}
+			if len(r.Warnings) == 0 {
+				save()
+				return
+			}
+			// Avvisi (es. risorse sovrallocate): si può salvare comunque
+			var msg strings.Builder
+			for _, e := range r.Warnings {
+				msg.WriteString("⚠ ")
+				msg.WriteString(e.Error())
+				msg.WriteString("\n")
+			}
+			dialog.ShowConfirm("Validazione", "Avvisi:\n\n"+msg.String()+"\nSalvare comunque?", func(ok bool) {
+				if ok {
+					save()
 				}
 			}, w)
 		}),
@@ -146,21 +242,9 @@ func Run(root *core.Activity) {
 		}),
 	)
 
-	statusLabel := widget.NewLabel("")
-	updateStatus := func() {
-		totalPrice := root.CalculatePrice()
-		totalDur := root.CalculateDuration()
-		statusLabel.SetText(fmt.Sprintf("Totale: %.0f %s | Durata: %.0f %s", totalPrice, root.Price.Currency, totalDur, root.Duration.Unit))
-	}
-	updateStatus()
-	form.onRefresh = func() {
-		refreshTree()
-		updateStatus()
-	}
-
 	content := container.NewBorder(
 		container.NewVBox(toolbar, widget.NewSeparator()),
-		container.NewVBox(widget.NewSeparator(), statusLabel),
+		container.NewVBox(widget.NewSeparator(), container.NewBorder(nil, nil, nil, currencySelect, statusLabel)),
 		nil, nil,
 		split,
 	)
@@ -168,3 +252,35 @@ func Run(root *core.Activity) {
 	w.SetContent(content)
 	w.ShowAndRun()
 }
+
+// readCatalogFile legge un catalogo JSON o YAML dal percorso dato.
+func readCatalogFile(path string) (*catalog.Catalog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readCatalog(path, f)
+}
+
+// readCatalog legge un catalogo in YAML (.yaml, .yml) o JSON secondo l'estensione di path.
+func readCatalog(path string, r io.Reader) (*catalog.Catalog, error) {
+	if strings.HasSuffix(strings.ToLower(path), ".yaml") || strings.HasSuffix(strings.ToLower(path), ".yml") {
+		return catalog.ReadYAML(r)
+	}
+	return catalog.ReadJSON(r)
+}
+
+// showCatalogWarnings mostra i codici che il catalogo non ha risolto (nulla se non ce ne sono).
+func showCatalogWarnings(warnings []core.ValidationError, w fyne.Window) {
+	if len(warnings) == 0 {
+		return
+	}
+	var msg strings.Builder
+	for _, e := range warnings {
+		msg.WriteString("⚠ ")
+		msg.WriteString(e.Error())
+		msg.WriteString("\n")
+	}
+	dialog.ShowInformation("Catalogo", "Codici non risolti (restano i prezzi del file):\n\n"+msg.String(), w)
+}
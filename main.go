@@ -3,11 +3,13 @@ package main
 
 import (
 	"explosio/core"
+	"explosio/core/catalog"
 	"explosio/core/unit"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 )
@@ -32,6 +34,30 @@ func main() {
 		runGantt(os.Args[2:])
 	case "validate":
 		runValidate(os.Args[2:])
+	case "float":
+		runFloat(os.Args[2:])
+	case "pert":
+		runPERT(os.Args[2:])
+	case "simulate":
+		runSimulate(os.Args[2:])
+	case "baseline":
+		runBaseline(os.Args[2:])
+	case "variance":
+		runVariance(os.Args[2:])
+	case "evm":
+		runEVM(os.Args[2:])
+	case "cashflow":
+		runCashFlow(os.Args[2:])
+	case "quote":
+		runQuote(os.Args[2:])
+	case "bom":
+		runBOM(os.Args[2:])
+	case "resources":
+		runResources(os.Args[2:])
+	case "level":
+		runLevel(os.Args[2:])
+	case "catalog":
+		runCatalog(os.Args[2:])
 	case "gui":
 		runGUI()
 	case "help", "-h", "--help":
@@ -49,6 +75,8 @@ Usage:
   explosio              Run demo (default)
   explosio run          Run demo project
   explosio load <file>  Load project from JSON or YAML file and print
+    [-currency <code>]  Show totals in this currency (default: project reporting currency)
+    [-catalog <file>]   Resolve item codes from this catalog (default: the project catalog)
   explosio export       Export project to JSON or YAML
     -input <file>       Input file (JSON or YAML)
     -output <file>      Output file (default: stdout)
@@ -57,10 +85,70 @@ Usage:
     -input <file>       Input file (required)
     -price-range min-max  Filter by price range (e.g. 100-1000)
     -name <pattern>     Filter by name (substring match)
+    -currency <code>    Show prices converted with the project exchange rates
   explosio gantt       Print ASCII Gantt chart
     [-input <file>]     Input file (default: demo)
-    [-start YYYY-MM-DD] Project start date (default: today)
-  explosio validate    Validate project (circular deps, references, warnings)
+    [-start YYYY-MM-DD] Project start date (default: project start or today)
+    [-calendar]         Use Mon-Fri 8h calendar if the project has none
+    [-status YYYY-MM-DD] Status date: draw completed work, schedule remaining work from it
+  explosio float       Print ES/EF/LS/LF and total, free, independent, interfering float
+    [-input <file>]     Input file (default: demo)
+  explosio pert        PERT analysis: expected duration, variance, probability of finishing by a date
+    [-input <file>]     Input file (default: demo)
+    [-start YYYY-MM-DD] Project start date (default: project start or today)
+    [-by YYYY-MM-DD]    Print the probability of finishing by the end of this date
+  explosio simulate    Monte Carlo simulation of finish dates and costs
+    [-input <file>]     Input file (default: demo)
+    [-runs N]           Number of runs (default: 1000)
+    [-seed S]           Random seed for reproducible runs (default: random, printed)
+    [-start YYYY-MM-DD] Project start date (default: project start or today)
+    [-bins N]           Histogram bins (default: 10)
+  explosio baseline    Save the current schedule and costs as a named baseline in the project file
+    -input <file>       Input file (required)
+    -name <name>        Baseline name (default: baseline)
+    [-start YYYY-MM-DD] Project start date (default: project start or today)
+    [-output <file>]    Output file (default: overwrite the input)
+  explosio variance    Compare the current schedule and costs with a baseline
+    -input <file>       Input file (required)
+    -baseline <name>    Baseline name (default: baseline)
+    [-start YYYY-MM-DD] Project start date (default: project start or today)
+  explosio evm         Earned value (PV, EV, AC, SV, CV, SPI, CPI, EAC, ETC, VAC) as of a status date
+    [-input <file>]     Input file (default: demo)
+    [-start YYYY-MM-DD] Project start date (default: project start or today)
+    [-status YYYY-MM-DD] Status date (default: project status date or today)
+    [-curve <file>]     Write the S-curve (cumulative PV, EV, AC) as CSV
+    [-period day|week|month]  S-curve period (default: week)
+  explosio cashflow    Costs spread over the schedule per period (payment schedule)
+    [-input <file>]     Input file (default: demo)
+    [-start YYYY-MM-DD] Project start date (default: project start or today)
+    [-period day|week|month]  Bucket length (default: week)
+    [-materials start|end|linear]  When materials are paid (default: start)
+    [-csv <file>]       Also write the cash flow as CSV
+  explosio quote       Cost, discount, net, markup, tax and gross per category (project pricing policy)
+    [-input <file>]     Input file (default: demo)
+    [-currency <code>]  Quote currency (default: project reporting currency)
+    [-csv <file>]       Also write the quote as CSV
+  explosio bom         Bill of materials: identical materials merged across activities (purchasing list)
+    [-input <file>]     Input file (default: demo)
+    [-currency <code>]  Cost currency (default: project reporting currency)
+    [-format table|csv|json]  Output format (default: table)
+    [-output <file>]    Output file (default: stdout)
+  explosio catalog     Reusable price list of materials, roles and assets referenced by code
+    list -file <file> [-kind countable|measurable|human|asset]  List entries
+    search -file <file> <text>  Find entries by code, name, description or supplier
+    set -file <file> -code <code> [-kind k] [-name n] [-price p] [-currency c] [-unit u] [-per hour|day|...]
+        [-supplier s] [-description d] [-pack n] [-waste pct]  Add or update an entry
+    remove -file <file> -code <code>  Delete an entry
+  explosio resources   Resource workload histogram (hours and cost per period)
+    [-input <file>]     Input file (default: demo)
+    [-start YYYY-MM-DD] Project start date (default: project start or today)
+    [-period day|week|month]  Bucket length (default: week)
+    [-csv <file>]       Also write the workload as CSV
+  explosio level       Resolve resource over-allocations by delaying activities
+    -input <file>       Input file (required)
+    [-output <file>]    Write the leveled project (json or yaml by extension)
+    [-priority least-float|longest-duration|earliest-start]  Scheduling priority (default: least-float)
+  explosio validate    Validate project (circular deps, references, constraints, deadline)
     [-input <file>]     Input file (default: demo)
   explosio gui         Apri la finestra GUI desktop
 `)
@@ -69,7 +157,8 @@ Usage:
 func runDemo() {
 	homeRenovation := BuildDemoTree()
 	core.PrettyPrint([]*core.Activity{homeRenovation}, homeRenovation.CalculateCriticalPath())
-	fmt.Printf("\nTotal price: %.2f EUR\n", homeRenovation.CalculatePrice())
+	total := homeRenovation.CalculateMoney()
+	fmt.Printf("\nTotal price: %s EUR\n", total.Decimal(2))
 	fmt.Printf("Total duration: %.0f days\n", homeRenovation.CalculateDuration())
 	meas := homeRenovation.GetMeasurableMaterials()
 	countable := homeRenovation.GetCountableMaterials()
@@ -100,8 +189,10 @@ func runDemo() {
 
 func runLoad(args []string) {
 	fs := flag.NewFlagSet("load", flag.ExitOnError)
+	currency := fs.String("currency", "", "Show totals in this currency (default: project reporting currency)")
+	catalogPath := fs.String("catalog", "", "Catalog file resolving item codes (default: the project catalog)")
 	fs.Usage = func() {
-		fmt.Fprintln(os.Stderr, "Usage: explosio load <file>")
+		fmt.Fprintln(os.Stderr, "Usage: explosio load [-currency <code>] [-catalog <file>] <file>")
 	}
 	_ = fs.Parse(args)
 	if fs.NArg() < 1 {
@@ -121,10 +212,19 @@ func runLoad(args []string) {
 	if err != nil {
 		log.Fatalf("load %s: %v", path, err)
 	}
+	applyProjectCatalog(proj, path, *catalogPath)
 
 	root := proj.Root
-	core.PrettyPrint([]*core.Activity{root}, root.CalculateCriticalPath())
-	fmt.Printf("\nTotal price: %.2f %s\n", root.CalculatePrice(), root.Price.Currency)
+	core.PrettyPrintWithSlack([]*core.Activity{root}, proj.CalculateCriticalPath(), proj.CalculateSlack())
+	cb := root.CostBreakdown()
+	reporting := root.Price.Currency
+	if *currency != "" || len(proj.ExchangeRates) > 0 {
+		reporting = proj.CurrencyConversion(*currency, proj.ConversionDate()).Currency
+		if cb, err = proj.CostBreakdownIn(reporting, proj.ConversionDate()); err != nil {
+			log.Fatalf("convert to %s: %v", reporting, err)
+		}
+	}
+	fmt.Printf("\nTotal price: %.2f %s\n", cb.Total(), reporting)
 	fmt.Printf("Total duration: %.0f %s\n", root.CalculateDuration(), root.Duration.Unit)
 	meas := root.GetMeasurableMaterials()
 	countable := root.GetCountableMaterials()
@@ -132,8 +232,15 @@ func runLoad(args []string) {
 	hr := root.GetHumanResources()
 	assets := root.GetAssets()
 	fmt.Printf("Materials: %d measurable, %d countable, %d complex | Human resources: %d | Assets: %d\n", len(meas), len(countable), len(complexMat), len(hr), len(assets))
-	cb := root.CostBreakdown()
 	fmt.Printf("Cost breakdown: Activities %.2f | Materials %.2f | Human %.2f | Assets %.2f\n", cb.Activities, cb.Materials, cb.Human, cb.Assets)
+	if proj.Pricing != nil {
+		q, err := proj.Quote(reporting, proj.ConversionDate())
+		if err != nil {
+			log.Fatalf("quote in %s: %v", reporting, err)
+		}
+		fmt.Println()
+		core.PrintQuote(q)
+	}
 }
 
 func runExport(args []string) {
@@ -146,20 +253,7 @@ func runExport(args []string) {
 	}
 	_ = fs.Parse(args)
 
-	var proj *core.Project
-	if *input != "" {
-		f, err := os.Open(*input)
-		if err != nil {
-			log.Fatalf("open %s: %v", *input, err)
-		}
-		defer f.Close()
-		proj, err = readProject(*input, f)
-		if err != nil {
-			log.Fatalf("load %s: %v", *input, err)
-		}
-	} else {
-		proj = core.NewProject(BuildDemoTree())
-	}
+	proj := loadProjectOrDemo(*input)
 
 	out := os.Stdout
 	if *output != "" {
@@ -194,8 +288,9 @@ func runQuery(args []string) {
 	material := fs.String("material", "", "Filter activities using material (substring)")
 	resource := fs.String("resource", "", "Filter activities using human resource (substring)")
 	sortBy := fs.String("sort", "", "Sort by: name, price, duration")
+	currency := fs.String("currency", "", "Show prices in this currency, converted with the project exchange rates")
 	fs.Usage = func() {
-		fmt.Fprintln(os.Stderr, "Usage: explosio query -input <file> [-price-range min-max] [-name <pattern>] [-material <name>] [-resource <name>] [-sort name|price|duration]")
+		fmt.Fprintln(os.Stderr, "Usage: explosio query -input <file> [-price-range min-max] [-name <pattern>] [-material <name>] [-resource <name>] [-sort name|price|duration] [-currency <code>]")
 	}
 	_ = fs.Parse(args)
 
@@ -205,18 +300,10 @@ func runQuery(args []string) {
 		os.Exit(1)
 	}
 
-	f, err := os.Open(*input)
-	if err != nil {
-		log.Fatalf("open %s: %v", *input, err)
-	}
-	defer f.Close()
-
-	proj, err := readProject(*input, f)
-	if err != nil {
-		log.Fatalf("load %s: %v", *input, err)
-	}
+	proj := loadProjectFile(*input)
 
 	activities := proj.Root.GetActivities()
+	conv := proj.DurationConversion()
 	filtered := core.FilterActivities(activities, core.FilterOptions{
 		PriceMin:     parsePriceRangeMin(*priceRange),
 		PriceMax:     parsePriceRangeMax(*priceRange),
@@ -224,6 +311,7 @@ func runQuery(args []string) {
 		NameRegex:    *nameRegex,
 		MaterialName: *material,
 		ResourceName: *resource,
+		Conversion:   &conv,
 	})
 
 	switch strings.ToLower(*sortBy) {
@@ -236,75 +324,672 @@ func runQuery(args []string) {
 	}
 
 	for _, a := range filtered {
-		fmt.Printf("%s: %.2f %s\n", a.Name, a.CalculatePrice(), a.Price.Currency)
+		if *currency == "" {
+			fmt.Printf("%s: %.2f %s\n", a.Name, a.CalculatePrice(), a.Price.Currency)
+			continue
+		}
+		price, err := a.CalculatePriceIn(proj.CurrencyConversion(*currency, proj.ConversionDate()))
+		if err != nil {
+			log.Fatalf("convert to %s: %v", *currency, err)
+		}
+		fmt.Printf("%s: %.2f %s\n", a.Name, price, *currency)
 	}
 }
 
 func runGantt(args []string) {
 	fs := flag.NewFlagSet("gantt", flag.ExitOnError)
 	input := fs.String("input", "", "Input file (default: demo)")
-	startStr := fs.String("start", "", "Project start date YYYY-MM-DD (default: today)")
+	startStr := fs.String("start", "", "Project start date YYYY-MM-DD (default: project start or today)")
+	useCalendar := fs.Bool("calendar", false, "Use the standard Mon-Fri 8h calendar if the project has none")
+	statusStr := fs.String("status", "", "Status date YYYY-MM-DD for progress (default: project status date)")
 	fs.Usage = func() {
-		fmt.Fprintln(os.Stderr, "Usage: explosio gantt [-input <file>] [-start YYYY-MM-DD]")
+		fmt.Fprintln(os.Stderr, "Usage: explosio gantt [-input <file>] [-start YYYY-MM-DD] [-calendar] [-status YYYY-MM-DD]")
 	}
 	_ = fs.Parse(args)
 
-	var root *core.Activity
-	if *input != "" {
-		f, err := os.Open(*input)
+	proj := loadProjectOrDemo(*input)
+	if proj.Calendar == nil && *useCalendar {
+		proj.Calendar = unit.NewCalendar()
+	}
+
+	projectStart := projectStartDate(proj, *startStr)
+	if *statusStr != "" {
+		status, err := unit.ParseDate(*statusStr)
 		if err != nil {
-			log.Fatalf("open %s: %v", *input, err)
+			log.Fatalf("invalid status date %q: %v", *statusStr, err)
 		}
-		defer f.Close()
-		proj, err := readProject(*input, f)
-		if err != nil {
-			log.Fatalf("load %s: %v", *input, err)
+		proj.StatusDate = &status
+	}
+
+	proj.PrintGantt(core.GanttConfig{
+		ProjectStart: projectStart,
+		Width:        50,
+		ShowDates:    true,
+	})
+}
+
+func runFloat(args []string) {
+	fs := flag.NewFlagSet("float", flag.ExitOnError)
+	input := fs.String("input", "", "Input file (default: demo)")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: explosio float [-input <file>]")
+	}
+	_ = fs.Parse(args)
+
+	proj := loadProjectOrDemo(*input)
+
+	core.PrintFloatTable(proj.Root.GetActivities(), proj.CalculateSlack())
+}
+
+func runPERT(args []string) {
+	fs := flag.NewFlagSet("pert", flag.ExitOnError)
+	input := fs.String("input", "", "Input file (default: demo)")
+	startStr := fs.String("start", "", "Project start date YYYY-MM-DD (default: project start or today)")
+	byStr := fs.String("by", "", "Target finish date YYYY-MM-DD")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: explosio pert [-input <file>] [-start YYYY-MM-DD] [-by YYYY-MM-DD]")
+	}
+	_ = fs.Parse(args)
+
+	proj := loadProjectOrDemo(*input)
+
+	if *byStr == "" {
+		core.PrintPERT(proj.PERT())
+		return
+	}
+	by, err := unit.ParseDate(*byStr)
+	if err != nil {
+		log.Fatalf("invalid -by date: %v", err)
+	}
+	projectStart := projectStartDate(proj, *startStr)
+	r, p := proj.PERTProbability(projectStart, by)
+	core.PrintPERT(r)
+	fmt.Printf("Probability of finishing by %s (start %s): %.1f%%\n", by.String(), projectStart.String(), p*100)
+}
+
+func runSimulate(args []string) {
+	fs := flag.NewFlagSet("simulate", flag.ExitOnError)
+	input := fs.String("input", "", "Input file (default: demo)")
+	runs := fs.Int("runs", 1000, "Number of runs")
+	seed := fs.Int64("seed", 0, "Random seed (default: random)")
+	startStr := fs.String("start", "", "Project start date YYYY-MM-DD (default: project start or today)")
+	bins := fs.Int("bins", 10, "Histogram bins")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: explosio simulate [-input <file>] [-runs N] [-seed S] [-start YYYY-MM-DD] [-bins N]")
+	}
+	_ = fs.Parse(args)
+
+	proj := loadProjectOrDemo(*input)
+
+	seedSet := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			seedSet = true
+		}
+	})
+	if !seedSet {
+		*seed = time.Now().UnixNano()
+	}
+	projectStart := projectStartDate(proj, *startStr)
+
+	r, err := proj.Simulate(core.SimulationConfig{Runs: *runs, Seed: *seed, Start: projectStart})
+	if err != nil {
+		log.Fatalf("simulate: %v", err)
+	}
+	fmt.Printf("Seed: %d | Start: %s\n", *seed, projectStart.String())
+	core.PrintSimulation(r, proj.Root.GetActivities(), *bins)
+}
+
+func runBaseline(args []string) {
+	fs := flag.NewFlagSet("baseline", flag.ExitOnError)
+	input := fs.String("input", "", "Input file (JSON or YAML)")
+	name := fs.String("name", "baseline", "Baseline name")
+	startStr := fs.String("start", "", "Project start date YYYY-MM-DD (default: project start or today)")
+	output := fs.String("output", "", "Output file (default: overwrite the input)")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: explosio baseline -input <file> [-name <name>] [-start YYYY-MM-DD] [-output <file>]")
+	}
+	_ = fs.Parse(args)
+
+	if *input == "" {
+		fs.Usage()
+		os.Exit(1)
+	}
+	proj := loadProjectFile(*input)
+	b, err := proj.SetBaseline(*name, projectStartDate(proj, *startStr))
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	path := *output
+	if path == "" {
+		path = *input
+	}
+	writeProjectFile(proj, path)
+	fmt.Printf("Baseline %q saved to %s (%d activities)\n", b.Name, path, len(b.Activities))
+}
+
+func runVariance(args []string) {
+	fs := flag.NewFlagSet("variance", flag.ExitOnError)
+	input := fs.String("input", "", "Input file (JSON or YAML)")
+	name := fs.String("baseline", "baseline", "Baseline name")
+	startStr := fs.String("start", "", "Project start date YYYY-MM-DD (default: project start or today)")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: explosio variance -input <file> [-baseline <name>] [-start YYYY-MM-DD]")
+	}
+	_ = fs.Parse(args)
+
+	if *input == "" {
+		fs.Usage()
+		os.Exit(1)
+	}
+	proj := loadProjectFile(*input)
+	report, err := proj.Variance(*name, projectStartDate(proj, *startStr))
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	core.PrintVariance(report)
+}
+
+// loadProjectFile reads a project from a JSON or YAML file, exiting on error.
+func loadProjectFile(path string) *core.Project {
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("open %s: %v", path, err)
+	}
+	defer f.Close()
+	proj, err := readProject(path, f)
+	if err != nil {
+		log.Fatalf("load %s: %v", path, err)
+	}
+	applyProjectCatalog(proj, path, "")
+	return proj
+}
+
+// loadProjectOrDemo reads the project file like loadProjectFile, or returns the demo project if path is empty.
+func loadProjectOrDemo(path string) *core.Project {
+	if path == "" {
+		return core.NewProject(BuildDemoTree())
+	}
+	return loadProjectFile(path)
+}
+
+// applyProjectCatalog resolves item codes from the catalog file (default: the project catalog, relative to the
+// project file). Codes that cannot be resolved are printed as warnings and keep the prices of the project file.
+func applyProjectCatalog(proj *core.Project, projectPath string, catalogPath string) {
+	if catalogPath == "" {
+		if proj.Catalog == "" {
+			return
+		}
+		catalogPath = proj.Catalog
+		if !filepath.IsAbs(catalogPath) {
+			catalogPath = filepath.Join(filepath.Dir(projectPath), catalogPath)
 		}
-		root = proj.Root
+	}
+	c := loadCatalogFile(catalogPath)
+	for _, p := range proj.ApplyCatalog(c) {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", p.Error())
+	}
+}
+
+// isYAMLPath returns true for .yaml and .yml files.
+func isYAMLPath(path string) bool {
+	return strings.HasSuffix(strings.ToLower(path), ".yaml") || strings.HasSuffix(strings.ToLower(path), ".yml")
+}
+
+// loadCatalogFile reads a catalog from JSON or YAML, exiting on error.
+func loadCatalogFile(path string) *catalog.Catalog {
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("open catalog %s: %v", path, err)
+	}
+	defer f.Close()
+	var c *catalog.Catalog
+	if isYAMLPath(path) {
+		c, err = catalog.ReadYAML(f)
+	} else {
+		c, err = catalog.ReadJSON(f)
+	}
+	if err != nil {
+		log.Fatalf("load catalog %s: %v", path, err)
+	}
+	return c
+}
+
+// writeCatalogFile writes the catalog as YAML (.yaml, .yml) or JSON, exiting on error.
+func writeCatalogFile(c *catalog.Catalog, path string) {
+	out, err := os.Create(path)
+	if err != nil {
+		log.Fatalf("create %s: %v", path, err)
+	}
+	defer out.Close()
+	if isYAMLPath(path) {
+		err = c.WriteYAML(out)
 	} else {
-		root = BuildDemoTree()
+		err = c.WriteJSON(out)
+	}
+	if err != nil {
+		log.Fatalf("write %s: %v", path, err)
 	}
+}
 
-	projectStart := unit.NewDate(time.Now().Year(), time.Now().Month(), time.Now().Day())
-	if *startStr != "" {
-		var y, m, d int
-		if _, err := fmt.Sscanf(*startStr, "%d-%d-%d", &y, &m, &d); err == nil {
-			projectStart = unit.NewDate(y, time.Month(m), d)
+// writeProjectFile writes the project as YAML (.yaml, .yml) or JSON, exiting on error.
+func writeProjectFile(proj *core.Project, path string) {
+	out, err := os.Create(path)
+	if err != nil {
+		log.Fatalf("create %s: %v", path, err)
+	}
+	defer out.Close()
+	if strings.HasSuffix(strings.ToLower(path), ".yaml") || strings.HasSuffix(strings.ToLower(path), ".yml") {
+		err = proj.WriteYAML(out)
+	} else {
+		err = proj.WriteJSON(out)
+	}
+	if err != nil {
+		log.Fatalf("write %s: %v", path, err)
+	}
+}
+
+// projectStartDate returns the start date from the flag value, else the project start, else today.
+func projectStartDate(proj *core.Project, flagValue string) unit.Date {
+	if flagValue != "" {
+		d, err := unit.ParseDate(flagValue)
+		if err != nil {
+			log.Fatalf("invalid start date %q: %v", flagValue, err)
 		}
+		return d
 	}
+	if proj.Start != nil {
+		return *proj.Start
+	}
+	return unit.NewDate(time.Now().Year(), time.Now().Month(), time.Now().Day())
+}
 
-	root.PrintGantt(core.GanttConfig{
-		ProjectStart: projectStart,
-		Width:        50,
-		ShowDates:    true,
+func runEVM(args []string) {
+	fs := flag.NewFlagSet("evm", flag.ExitOnError)
+	input := fs.String("input", "", "Input file (default: demo)")
+	startStr := fs.String("start", "", "Project start date YYYY-MM-DD (default: project start or today)")
+	statusStr := fs.String("status", "", "Status date YYYY-MM-DD (default: project status date or today)")
+	curvePath := fs.String("curve", "", "S-curve CSV output file")
+	period := fs.String("period", string(core.PeriodWeek), "S-curve period: day, week or month")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: explosio evm [-input <file>] [-start YYYY-MM-DD] [-status YYYY-MM-DD] [-curve <file>] [-period day|week|month]")
+	}
+	_ = fs.Parse(args)
+
+	if !core.IsValidLoadPeriod(core.LoadPeriod(*period)) {
+		log.Fatalf("unsupported period: %s (use day, week or month)", *period)
+	}
+	proj := loadProjectOrDemo(*input)
+	projectStart := projectStartDate(proj, *startStr)
+	status := unit.NewDate(time.Now().Year(), time.Now().Month(), time.Now().Day())
+	if proj.StatusDate != nil {
+		status = *proj.StatusDate
+	}
+	if *statusStr != "" {
+		d, err := unit.ParseDate(*statusStr)
+		if err != nil {
+			log.Fatalf("invalid status date %q: %v", *statusStr, err)
+		}
+		status = d
+	}
+
+	report, err := proj.EarnedValue(projectStart, status)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	core.PrintEVM(report)
+
+	if *curvePath != "" {
+		points, err := proj.SCurve(projectStart, status, core.LoadPeriod(*period))
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		out, err := os.Create(*curvePath)
+		if err != nil {
+			log.Fatalf("create %s: %v", *curvePath, err)
+		}
+		defer out.Close()
+		if err := core.WriteSCurveCSV(out, points); err != nil {
+			log.Fatalf("write %s: %v", *curvePath, err)
+		}
+	}
+}
+
+func runCashFlow(args []string) {
+	fs := flag.NewFlagSet("cashflow", flag.ExitOnError)
+	input := fs.String("input", "", "Input file (default: demo)")
+	startStr := fs.String("start", "", "Project start date YYYY-MM-DD (default: project start or today)")
+	period := fs.String("period", string(core.PeriodWeek), "Period: day, week or month")
+	materials := fs.String("materials", string(core.AccrueStart), "Material accrual: start, end or linear")
+	csvPath := fs.String("csv", "", "CSV output file")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: explosio cashflow [-input <file>] [-start YYYY-MM-DD] [-period day|week|month] [-materials start|end|linear] [-csv <file>]")
+	}
+	_ = fs.Parse(args)
+
+	if !core.IsValidLoadPeriod(core.LoadPeriod(*period)) {
+		log.Fatalf("unsupported period: %s (use day, week or month)", *period)
+	}
+	if !core.IsValidAccrualMethod(core.AccrualMethod(*materials)) {
+		log.Fatalf("unsupported material accrual: %s (use start, end or linear)", *materials)
+	}
+	proj := loadProjectOrDemo(*input)
+
+	cf, err := proj.CashFlow(projectStartDate(proj, *startStr), core.CashFlowConfig{
+		Period:          core.LoadPeriod(*period),
+		MaterialAccrual: core.AccrualMethod(*materials),
 	})
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	core.PrintCashFlow(cf)
+
+	if *csvPath != "" {
+		out, err := os.Create(*csvPath)
+		if err != nil {
+			log.Fatalf("create %s: %v", *csvPath, err)
+		}
+		defer out.Close()
+		if err := core.WriteCashFlowCSV(out, cf); err != nil {
+			log.Fatalf("write %s: %v", *csvPath, err)
+		}
+	}
 }
 
-func runValidate(args []string) {
-	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+func runQuote(args []string) {
+	fs := flag.NewFlagSet("quote", flag.ExitOnError)
 	input := fs.String("input", "", "Input file (default: demo)")
+	currency := fs.String("currency", "", "Quote currency (default: project reporting currency)")
+	csvPath := fs.String("csv", "", "CSV output file")
 	fs.Usage = func() {
-		fmt.Fprintln(os.Stderr, "Usage: explosio validate [-input <file>]")
+		fmt.Fprintln(os.Stderr, "Usage: explosio quote [-input <file>] [-currency <code>] [-csv <file>]")
+	}
+	_ = fs.Parse(args)
+
+	proj := loadProjectOrDemo(*input)
+
+	q, err := proj.Quote(*currency, proj.ConversionDate())
+	if err != nil {
+		log.Fatalf("quote: %v", err)
+	}
+	core.PrintQuote(q)
+
+	if *csvPath != "" {
+		out, err := os.Create(*csvPath)
+		if err != nil {
+			log.Fatalf("create %s: %v", *csvPath, err)
+		}
+		defer out.Close()
+		if err := core.WriteQuoteCSV(out, q); err != nil {
+			log.Fatalf("write %s: %v", *csvPath, err)
+		}
+	}
+}
+
+func runBOM(args []string) {
+	fs := flag.NewFlagSet("bom", flag.ExitOnError)
+	input := fs.String("input", "", "Input file (default: demo)")
+	currency := fs.String("currency", "", "Cost currency (default: project reporting currency)")
+	format := fs.String("format", "table", "Output format: table, csv or json")
+	output := fs.String("output", "", "Output file (default: stdout)")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: explosio bom [-input <file>] [-currency <code>] [-format table|csv|json] [-output <file>]")
 	}
 	_ = fs.Parse(args)
 
-	var root *core.Activity
-	if *input != "" {
-		f, err := os.Open(*input)
+	if *format != "table" && *format != "csv" && *format != "json" {
+		log.Fatalf("unsupported format: %s (use table, csv or json)", *format)
+	}
+	proj := loadProjectOrDemo(*input)
+
+	bom, err := proj.BOM(*currency, proj.ConversionDate())
+	if err != nil {
+		log.Fatalf("bom: %v", err)
+	}
+	if *format == "table" && *output == "" {
+		core.PrintBOM(bom)
+		return
+	}
+
+	out := os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
 		if err != nil {
-			log.Fatalf("open %s: %v", *input, err)
+			log.Fatalf("create %s: %v", *output, err)
 		}
 		defer f.Close()
-		proj, err := readProject(*input, f)
+		out = f
+	}
+	switch *format {
+	case "json":
+		err = core.WriteBOMJSON(out, bom)
+	default:
+		err = core.WriteBOMCSV(out, bom)
+	}
+	if err != nil {
+		log.Fatalf("write bom: %v", err)
+	}
+}
+
+func runCatalog(args []string) {
+	usage := func() {
+		fmt.Fprintln(os.Stderr, "Usage: explosio catalog list|search|set|remove -file <file> [options]")
+	}
+	if len(args) < 1 {
+		usage()
+		os.Exit(1)
+	}
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("catalog list", flag.ExitOnError)
+		file := fs.String("file", "", "Catalog file (JSON or YAML)")
+		kind := fs.String("kind", "", "Only entries of this kind: countable, measurable, human or asset")
+		fs.Usage = func() {
+			fmt.Fprintln(os.Stderr, "Usage: explosio catalog list -file <file> [-kind countable|measurable|human|asset]")
+		}
+		_ = fs.Parse(args[1:])
+		if *file == "" {
+			fs.Usage()
+			os.Exit(1)
+		}
+		c := loadCatalogFile(*file)
+		entries := c.Search("")
+		if *kind != "" {
+			if !catalog.Kind(*kind).IsValid() {
+				log.Fatalf("unknown kind: %s (use countable, measurable, human or asset)", *kind)
+			}
+			entries = c.OfKind(catalog.Kind(*kind))
+		}
+		catalog.PrintEntries(entries)
+	case "search":
+		fs := flag.NewFlagSet("catalog search", flag.ExitOnError)
+		file := fs.String("file", "", "Catalog file (JSON or YAML)")
+		fs.Usage = func() {
+			fmt.Fprintln(os.Stderr, "Usage: explosio catalog search -file <file> <text>")
+		}
+		_ = fs.Parse(args[1:])
+		if *file == "" || fs.NArg() < 1 {
+			fs.Usage()
+			os.Exit(1)
+		}
+		entries := loadCatalogFile(*file).Search(strings.Join(fs.Args(), " "))
+		if len(entries) == 0 {
+			fmt.Println("No entries found.")
+			return
+		}
+		catalog.PrintEntries(entries)
+	case "set":
+		runCatalogSet(args[1:])
+	case "remove":
+		fs := flag.NewFlagSet("catalog remove", flag.ExitOnError)
+		file := fs.String("file", "", "Catalog file (JSON or YAML)")
+		code := fs.String("code", "", "Entry code")
+		fs.Usage = func() {
+			fmt.Fprintln(os.Stderr, "Usage: explosio catalog remove -file <file> -code <code>")
+		}
+		_ = fs.Parse(args[1:])
+		if *file == "" || *code == "" {
+			fs.Usage()
+			os.Exit(1)
+		}
+		c := loadCatalogFile(*file)
+		if !c.Remove(*code) {
+			log.Fatalf("catalog %s: code %q not found", *file, *code)
+		}
+		writeCatalogFile(c, *file)
+		fmt.Printf("Removed %s from %s\n", *code, *file)
+	default:
+		usage()
+		os.Exit(1)
+	}
+}
+
+// runCatalogSet adds an entry or updates the flags given on the command line of an existing entry.
+// The catalog file is created if it does not exist.
+func runCatalogSet(args []string) {
+	fs := flag.NewFlagSet("catalog set", flag.ExitOnError)
+	file := fs.String("file", "", "Catalog file (JSON or YAML, created if missing)")
+	code := fs.String("code", "", "Entry code")
+	kind := fs.String("kind", "", "Kind: countable, measurable, human or asset (required for new entries)")
+	name := fs.String("name", "", "Name")
+	description := fs.String("description", "", "Description")
+	supplier := fs.String("supplier", "", "Supplier")
+	price := fs.Float64("price", 0, "Unit price (per piece, per unit or per rate unit)")
+	currency := fs.String("currency", "EUR", "Price currency")
+	measure := fs.String("unit", "", "Measurable: unit of the price and pack size (e.g. kg, m2)")
+	per := fs.String("per", "", "Human and asset: rate unit (hour, day, week, ...; empty = fixed price)")
+	pack := fs.Float64("pack", 0, "Pack size (pieces, or quantity in -unit)")
+	waste := fs.Float64("waste", 0, "Default waste percent")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: explosio catalog set -file <file> -code <code> [-kind k] [-name n] [-price p] [-currency c] [-unit u] [-per hour|day|...] [-supplier s] [-description d] [-pack n] [-waste pct]")
+	}
+	_ = fs.Parse(args)
+	if *file == "" || *code == "" {
+		fs.Usage()
+		os.Exit(1)
+	}
+
+	c := catalog.New()
+	if _, err := os.Stat(*file); err == nil {
+		c = loadCatalogFile(*file)
+	}
+	e := &catalog.Entry{Code: *code, Price: unit.Price{Currency: *currency}}
+	existing := c.Find(*code)
+	if existing != nil {
+		copied := *existing
+		e = &copied
+	}
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "kind":
+			e.Kind = catalog.Kind(*kind)
+		case "name":
+			e.Name = *name
+		case "description":
+			e.Description = *description
+		case "supplier":
+			e.Supplier = *supplier
+		case "price":
+			e.Price.Value = *price
+		case "currency":
+			e.Price.Currency = *currency
+		case "unit":
+			e.Unit = unit.MeasurableUnit(*measure)
+		case "per":
+			e.Per = unit.DurationUnit(*per)
+		case "pack":
+			e.PackSize = *pack
+		case "waste":
+			e.WastePercent = *waste
+		}
+	})
+	if errs := e.Validate(); len(errs) > 0 {
+		for _, err := range errs {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		os.Exit(1)
+	}
+	c.Set(e)
+	writeCatalogFile(c, *file)
+	action := "Added"
+	if existing != nil {
+		action = "Updated"
+	}
+	fmt.Printf("%s %s (%s, %s) in %s\n", action, e.Code, e.Name, e.PriceLabel(), *file)
+}
+
+func runResources(args []string) {
+	fs := flag.NewFlagSet("resources", flag.ExitOnError)
+	input := fs.String("input", "", "Input file (default: demo)")
+	startStr := fs.String("start", "", "Project start date YYYY-MM-DD (default: project start or today)")
+	period := fs.String("period", string(core.PeriodWeek), "Period: day, week or month")
+	csvPath := fs.String("csv", "", "CSV output file")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: explosio resources [-input <file>] [-start YYYY-MM-DD] [-period day|week|month] [-csv <file>]")
+	}
+	_ = fs.Parse(args)
+
+	if !core.IsValidLoadPeriod(core.LoadPeriod(*period)) {
+		log.Fatalf("unsupported period: %s (use day, week or month)", *period)
+	}
+	proj := loadProjectOrDemo(*input)
+
+	w, err := proj.Workload(projectStartDate(proj, *startStr), core.LoadPeriod(*period))
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	core.PrintWorkload(w, 40)
+
+	if *csvPath != "" {
+		out, err := os.Create(*csvPath)
 		if err != nil {
-			log.Fatalf("load %s: %v", *input, err)
+			log.Fatalf("create %s: %v", *csvPath, err)
 		}
-		root = proj.Root
-	} else {
-		root = BuildDemoTree()
+		defer out.Close()
+		if err := core.WriteWorkloadCSV(out, w); err != nil {
+			log.Fatalf("write %s: %v", *csvPath, err)
+		}
+	}
+}
+
+func runLevel(args []string) {
+	fs := flag.NewFlagSet("level", flag.ExitOnError)
+	input := fs.String("input", "", "Input file (JSON or YAML)")
+	output := fs.String("output", "", "Output file for the leveled project (.json or .yaml)")
+	priority := fs.String("priority", string(core.PriorityLeastFloat), "Priority: least-float, longest-duration or earliest-start")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: explosio level -input <file> [-output <file>] [-priority least-float|longest-duration|earliest-start]")
+	}
+	_ = fs.Parse(args)
+
+	if *input == "" {
+		fs.Usage()
+		os.Exit(1)
+	}
+	if !core.IsValidLevelingPriority(core.LevelingPriority(*priority)) {
+		log.Fatalf("unsupported priority: %s (use least-float, longest-duration or earliest-start)", *priority)
 	}
+	proj := loadProjectFile(*input)
+
+	core.PrintLevelingReport(proj.Level(core.LevelingPriority(*priority)))
+
+	if *output != "" {
+		writeProjectFile(proj, *output)
+	}
+}
+
+func runValidate(args []string) {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	input := fs.String("input", "", "Input file (default: demo)")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: explosio validate [-input <file>]")
+	}
+	_ = fs.Parse(args)
+
+	proj := loadProjectOrDemo(*input)
 
-	r := root.Validate()
+	r := proj.Validate()
 	for _, e := range r.Errors {
 		fmt.Printf("Error: %s\n", e.Error())
 	}